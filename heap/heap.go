@@ -63,6 +63,91 @@ func (h *Heap[T]) Top() T {
 	return h.data[0]
 }
 
+// Returns the top element without removing it.
+// Boolean is false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	if len(h.data) == 0 {
+		return *new(T), false
+	}
+
+	return h.data[0], true
+}
+
+// Returns the element at index i.
+// Index 0 is the top of the heap, other indexes follow the heap's internal order.
+// Boolean is false if the index is out of range.
+func (h *Heap[T]) At(i int) (T, bool) {
+	if i < 0 || i >= len(h.data) {
+		return *new(T), false
+	}
+
+	return h.data[i], true
+}
+
+// Returns the index of the first element satisfying f, or -1 if there is none.
+// The index can be passed to Remove, Fix and Update until the heap is changed again.
+// Runs in O(n) time.
+func (h *Heap[T]) IndexFunc(f func(T) bool) int {
+	for i, val := range h.data {
+		if f(val) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Removes the top element and returns it.
+// Boolean is false if the heap is empty.
+func (h *Heap[T]) PopValue() (T, bool) {
+	return h.Remove(0)
+}
+
+// Removes the element at index i and returns it, same as container/heap.Remove.
+// Index 0 is the top of the heap, use IndexFunc to find any other element.
+// Boolean is false if the index is out of range.
+// Runs in O(log n) time.
+func (h *Heap[T]) Remove(i int) (T, bool) {
+	if i < 0 || i >= len(h.data) {
+		return *new(T), false
+	}
+
+	removed := h.data[i]
+	lastIndex := len(h.data) - 1
+	h.data[i] = h.data[lastIndex]
+	h.data[lastIndex] = *new(T) // do not keep the removed element alive
+	h.data = h.data[:lastIndex]
+
+	if i < len(h.data) {
+		h.fix(i)
+	}
+	return removed, true
+}
+
+// Restores heap order after the element at index i changed, same as container/heap.Fix.
+// Returns false if the index is out of range.
+// Runs in O(log n) time.
+func (h *Heap[T]) Fix(i int) bool {
+	if i < 0 || i >= len(h.data) {
+		return false
+	}
+
+	h.fix(i)
+	return true
+}
+
+// Replaces the element at index i with val and restores heap order.
+// Returns false if the index is out of range.
+// Runs in O(log n) time.
+func (h *Heap[T]) Update(i int, val T) bool {
+	if i < 0 || i >= len(h.data) {
+		return false
+	}
+
+	h.data[i] = val
+	h.fix(i)
+	return true
+}
+
 func (h *Heap[T]) Size() int {
 	return len(h.data)
 }
@@ -71,6 +156,15 @@ func (h *Heap[T]) IsEmpty() bool {
 	return len(h.data) == 0
 }
 
+// Moves the element at index up or down, whichever way restores heap order.
+func (h *Heap[T]) fix(index int) {
+	if index > 0 && h.less(h.data[index], h.data[(index-1)/2]) {
+		h.heapifyUp(index)
+	} else {
+		h.heapifyDown(index)
+	}
+}
+
 func (h *Heap[T]) heapifyUp(index int) {
 	currentIndex := index
 	for currentIndex > 0 {
@@ -199,3 +199,211 @@ func TestNewHeapWithFunc(t *testing.T) {
 		}
 	})
 }
+
+// Helper function to verify every element is ordered before it's children
+func verifyHeapProperty[T any](t *testing.T, h *Heap[T]) {
+	for index := 1; index < len(h.data); index++ {
+		parentIndex := (index - 1) / 2
+		if h.less(h.data[index], h.data[parentIndex]) {
+			t.Errorf("heap property violated: element at %d ordered before it's parent at %d", index, parentIndex)
+		}
+	}
+}
+
+func TestPeekAndPopValue(t *testing.T) {
+	t.Run("empty heap reports empty instead of returning a bare zero value", func(t *testing.T) {
+		t.Parallel()
+		h := NewMinHeap[int]()
+
+		if val, ok := h.Peek(); ok || val != 0 {
+			t.Errorf("Expected 0, false from Peek on empty heap, got %d, %v", val, ok)
+		}
+
+		if val, ok := h.PopValue(); ok || val != 0 {
+			t.Errorf("Expected 0, false from PopValue on empty heap, got %d, %v", val, ok)
+		}
+	})
+
+	t.Run("zero value elements are told apart from an empty heap", func(t *testing.T) {
+		t.Parallel()
+		h := NewMinHeap[int]()
+		h.Push(0)
+
+		if val, ok := h.Peek(); !ok || val != 0 {
+			t.Errorf("Expected 0, true from Peek, got %d, %v", val, ok)
+		}
+
+		if h.Size() != 1 {
+			t.Errorf("Peek should not remove the element, size is %d", h.Size())
+		}
+
+		if val, ok := h.PopValue(); !ok || val != 0 {
+			t.Errorf("Expected 0, true from PopValue, got %d, %v", val, ok)
+		}
+
+		if !h.IsEmpty() {
+			t.Error("PopValue should remove the element")
+		}
+	})
+
+	t.Run("pop values of a max heap in sorted order", func(t *testing.T) {
+		t.Parallel()
+		h := NewMaxHeap[string]()
+
+		for _, val := range []string{"pear", "apple", "fig", "kiwi"} {
+			h.Push(val)
+		}
+
+		expected := []string{"pear", "kiwi", "fig", "apple"}
+		for index := range expected {
+			val, ok := h.PopValue()
+			if !ok || val != expected[index] {
+				t.Errorf("Expected %s, got %s, %v", expected[index], val, ok)
+			}
+		}
+	})
+}
+
+func TestRemove(t *testing.T) {
+	t.Run("remove elements from every position and keep heap property", func(t *testing.T) {
+		t.Parallel()
+		h := NewMinHeap[int]()
+
+		for _, val := range []int{5, 3, 7, 1, 9, 2, 8, 4, 6, 0} {
+			h.Push(val)
+		}
+
+		removed := make(map[int]bool)
+		for _, index := range []int{9, 4, 0, 3, 5} {
+			val, ok := h.Remove(index)
+			if !ok {
+				t.Fatalf("Remove(%d) failed on heap of size %d", index, h.Size())
+			}
+			removed[val] = true
+			verifyHeapProperty(t, h)
+		}
+
+		if h.Size() != 5 {
+			t.Errorf("Expected heap size to be 5, got %d", h.Size())
+		}
+
+		// remaining elements come out in order and are exactly the ones not removed
+		previous := -1
+		for !h.IsEmpty() {
+			val, _ := h.PopValue()
+			if removed[val] {
+				t.Errorf("Element %d was removed but is still in the heap", val)
+			}
+			if val < previous {
+				t.Errorf("Min heap property violated: %d after %d", val, previous)
+			}
+			previous = val
+		}
+	})
+
+	t.Run("remove with index out of range", func(t *testing.T) {
+		t.Parallel()
+		h := NewMinHeap[int]()
+		h.Push(1)
+
+		for _, index := range []int{-1, 1} {
+			if _, ok := h.Remove(index); ok {
+				t.Errorf("Remove(%d) should fail on heap of size 1", index)
+			}
+		}
+
+		if h.Size() != 1 {
+			t.Errorf("Failed Remove should not change the heap, size is %d", h.Size())
+		}
+	})
+}
+
+func TestFixAndUpdate(t *testing.T) {
+	t.Run("update elements to move them up and down", func(t *testing.T) {
+		t.Parallel()
+		h := NewMinHeap[int]()
+
+		for _, val := range []int{10, 20, 30, 40, 50, 60, 70} {
+			h.Push(val)
+		}
+
+		// last element becomes the smallest, top element becomes the largest
+		if !h.Update(h.Size()-1, 5) {
+			t.Fatal("Update of last element failed")
+		}
+		verifyHeapProperty(t, h)
+
+		if !h.Update(0, 100) {
+			t.Fatal("Update of top element failed")
+		}
+		verifyHeapProperty(t, h)
+
+		// update an element found by value instead of position
+		index := h.IndexFunc(func(val int) bool { return val == 40 })
+		if !h.Update(index, 1) {
+			t.Fatalf("Update(%d) failed", index)
+		}
+		verifyHeapProperty(t, h)
+
+		expected := []int{1, 10, 20, 30, 50, 60, 100}
+		for index := range expected {
+			if val, _ := h.PopValue(); val != expected[index] {
+				t.Errorf("Expected %d, got %d", expected[index], val)
+			}
+		}
+	})
+
+	t.Run("fix element changed through a pointer", func(t *testing.T) {
+		t.Parallel()
+		type task struct {
+			name     string
+			priority int
+		}
+
+		h := NewHeapWithFunc(func(a, b *task) bool {
+			return a.priority > b.priority // max heap
+		})
+
+		tasks := []*task{{"write", 3}, {"test", 2}, {"review", 1}}
+		for _, val := range tasks {
+			h.Push(val)
+		}
+
+		// find the lowest priority task in the heap and make it the most urgent one
+		index := h.IndexFunc(func(val *task) bool { return val.name == "review" })
+		review, ok := h.At(index)
+		if !ok {
+			t.Fatalf("IndexFunc returned %d, expected index of review", index)
+		}
+		review.priority = 10
+		if !h.Fix(index) {
+			t.Fatalf("Fix(%d) failed", index)
+		}
+		verifyHeapProperty(t, h)
+
+		if top, _ := h.Peek(); top.name != "review" {
+			t.Errorf("Expected review on top after Fix, got %s", top.name)
+		}
+	})
+
+	t.Run("fix and update with index out of range", func(t *testing.T) {
+		t.Parallel()
+		h := NewMaxHeap[int]()
+
+		if h.Fix(0) || h.Update(0, 1) {
+			t.Error("Fix and Update should fail on empty heap")
+		}
+
+		h.Push(1)
+		if h.Fix(1) || h.Update(-1, 1) {
+			t.Error("Fix and Update should fail with index out of range")
+		}
+
+		if index := h.IndexFunc(func(val int) bool { return val == 2 }); index != -1 {
+			t.Errorf("IndexFunc should return -1 for a missing element, got %d", index)
+		}
+		if _, ok := h.At(1); ok {
+			t.Error("At should fail with index out of range")
+		}
+	})
+}
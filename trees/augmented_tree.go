@@ -0,0 +1,152 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// Monoid describes how per-node measures are combined into subtree aggregates.
+// Combine must be associative and Identity must satisfy Combine(Identity, a) == Combine(a, Identity) == a.
+// Combine does not need to be commutative, measures are always combined in key order.
+type Monoid[A any] struct {
+	Identity A
+	Combine  func(a, b A) A
+}
+
+// AugmentedTree is a red black tree which keeps an aggregate of every subtree,
+// for example the sum or the maximum of some measure of it's key-value pairs.
+// Aggregates are maintained through insertion, deletion and all rotations,
+// so aggregating any key range takes O(log n) time instead of walking the nodes.
+type AugmentedTree[T any, V any, A any] struct {
+	tree    *RedBlackTree[T, augmentedValue[V, A]]
+	monoid  Monoid[A]
+	measure func(key T, value V) A
+}
+
+// Value stored in the underlying tree, the user value together with the aggregate of the node's subtree.
+type augmentedValue[V any, A any] struct {
+	value     V
+	aggregate A
+}
+
+// Returns a pointer to an empty AugmentedTree.
+// Works with default built in key types.
+// 'measure' maps a key-value pair to the quantity being aggregated, 'monoid' combines them.
+func NewAugmentedTree[T cmp.Ordered, V any, A any](monoid Monoid[A], measure func(key T, value V) A) *AugmentedTree[T, V, A] {
+	return NewAugmentedTreeWithFunc(cmp.Compare[T], monoid, measure)
+}
+
+// Returns a pointer to an empty AugmentedTree.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewAugmentedTreeWithFunc[T any, V any, A any](comparator func(a, b T) int, monoid Monoid[A], measure func(key T, value V) A) *AugmentedTree[T, V, A] {
+	a := &AugmentedTree[T, V, A]{
+		tree:    NewRedBlackTreeWithFunc[T, augmentedValue[V, A]](comparator),
+		monoid:  monoid,
+		measure: measure,
+	}
+	a.tree.augment = a.updateAggregate
+	return a
+}
+
+// Inserts a key-value pair into the tree.
+// If the key already exists, it's value is updated.
+func (a *AugmentedTree[T, V, A]) Insert(key T, value V) {
+	a.tree.Insert(key, augmentedValue[V, A]{value: value})
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (a *AugmentedTree[T, V, A]) Delete(key T) bool {
+	return a.tree.Delete(key)
+}
+
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (a *AugmentedTree[T, V, A]) Search(key T) (V, bool) {
+	node, ok := a.tree.Search(key)
+	return node.Value.value, ok
+}
+
+// Returns the current number of keys in the tree.
+func (a *AugmentedTree[T, V, A]) Size() int {
+	return a.tree.Size()
+}
+
+// Returns true if tree is empty, otherwise false.
+func (a *AugmentedTree[T, V, A]) IsEmpty() bool {
+	return a.tree.IsEmpty()
+}
+
+// Clears and resets the tree to an empty tree.
+func (a *AugmentedTree[T, V, A]) Clear() {
+	a.tree.Clear()
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (a *AugmentedTree[T, V, A]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		for key, value := range a.tree.ForwardIterator() {
+			if !yield(key, value.value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the aggregate of the whole tree in O(1) time.
+// Returns the monoid identity if the tree is empty.
+func (a *AugmentedTree[T, V, A]) AggregateAll() A {
+	return a.subtreeAggregate(a.tree.Root)
+}
+
+// Returns the aggregate of all key-value pairs with keys in the closed interval [lo, hi].
+// Returns the monoid identity if no key lies in the interval.
+// Runs in O(log n) time.
+func (a *AugmentedTree[T, V, A]) Aggregate(lo, hi T) A {
+	return a.aggregate(a.tree.Root, &lo, &hi)
+}
+
+// Returns the aggregate of keys in the subtree rooted at 'node' which lie within the bounds.
+// A nil bound means the subtree is not limited on that side.
+// Once the search paths for lo and hi split, each side only has one bound left,
+// so whole subtrees can be taken from their cached aggregates and only two paths are walked.
+func (a *AugmentedTree[T, V, A]) aggregate(node *RedBlackTreeNode[T, augmentedValue[V, A]], lo, hi *T) A {
+	if node == a.tree.NIL {
+		return a.monoid.Identity
+	}
+
+	if lo == nil && hi == nil {
+		return node.Value.aggregate
+	}
+
+	if lo != nil && a.tree.compare(node.Key, *lo) < 0 {
+		return a.aggregate(node.Right, lo, hi)
+	}
+
+	if hi != nil && a.tree.compare(node.Key, *hi) > 0 {
+		return a.aggregate(node.Left, lo, hi)
+	}
+
+	// node lies within the bounds, everything on the left is below hi and everything on the right is above lo
+	left := a.aggregate(node.Left, lo, nil)
+	right := a.aggregate(node.Right, nil, hi)
+	return a.monoid.Combine(a.monoid.Combine(left, a.measure(node.Key, node.Value.value)), right)
+}
+
+// Returns the cached aggregate of the subtree rooted at 'node', identity for the NIL node.
+func (a *AugmentedTree[T, V, A]) subtreeAggregate(node *RedBlackTreeNode[T, augmentedValue[V, A]]) A {
+	if node == a.tree.NIL {
+		return a.monoid.Identity
+	}
+	return node.Value.aggregate
+}
+
+// Recomputes the aggregate of 'node' from it's children, called by the underlying tree.
+func (a *AugmentedTree[T, V, A]) updateAggregate(node *RedBlackTreeNode[T, augmentedValue[V, A]]) {
+	left := a.subtreeAggregate(node.Left)
+	right := a.subtreeAggregate(node.Right)
+	node.Value.aggregate = a.monoid.Combine(a.monoid.Combine(left, a.measure(node.Key, node.Value.value)), right)
+}
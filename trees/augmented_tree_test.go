@@ -0,0 +1,168 @@
+package trees
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var sumMonoid = Monoid[int]{
+	Identity: 0,
+	Combine:  func(a, b int) int { return a + b },
+}
+
+var maxMonoid = Monoid[int]{
+	Identity: math.MinInt,
+	Combine:  func(a, b int) int { return max(a, b) },
+}
+
+// Concatenation is not commutative, so it catches aggregates combined out of key order.
+var concatMonoid = Monoid[string]{
+	Identity: "",
+	Combine:  func(a, b string) string { return a + b },
+}
+
+// Verifies the cached aggregate of every node against a recomputation from scratch.
+func verifyAggregates[T any, V any, A comparable](t *testing.T, a *AugmentedTree[T, V, A], node *RedBlackTreeNode[T, augmentedValue[V, A]]) A {
+	if node == a.tree.NIL {
+		return a.monoid.Identity
+	}
+
+	left := verifyAggregates(t, a, node.Left)
+	right := verifyAggregates(t, a, node.Right)
+	expected := a.monoid.Combine(a.monoid.Combine(left, a.measure(node.Key, node.Value.value)), right)
+
+	if node.Value.aggregate != expected {
+		t.Errorf("Aggregate mismatch at key %v: stored %v, expected %v", node.Key, node.Value.aggregate, expected)
+	}
+	return expected
+}
+
+func TestAugmentedTree(t *testing.T) {
+	t.Run("test sum of values in key range", func(t *testing.T) {
+		a := NewAugmentedTree(sumMonoid, func(key int, bytes int) int { return bytes })
+		for i := 1; i <= 100; i++ {
+			a.Insert(i, i*10)
+		}
+		verifyAggregates(t, a, a.tree.Root)
+
+		if total := a.AggregateAll(); total != 50500 {
+			t.Errorf("Expected total 50500, got %d", total)
+		}
+
+		if total := a.Aggregate(10, 20); total != 1650 {
+			t.Errorf("Expected 1650 for [10, 20], got %d", total)
+		}
+
+		if total := a.Aggregate(200, 300); total != 0 {
+			t.Errorf("Expected identity for empty range, got %d", total)
+		}
+
+		if total := a.Aggregate(20, 10); total != 0 {
+			t.Errorf("Expected identity for inverted range, got %d", total)
+		}
+
+		a.Insert(15, 0) // update replaces the old value
+		if total := a.Aggregate(10, 20); total != 1500 {
+			t.Errorf("Expected 1500 after update, got %d", total)
+		}
+
+		a.Delete(10)
+		if total := a.Aggregate(10, 20); total != 1400 {
+			t.Errorf("Expected 1400 after delete, got %d", total)
+		}
+		verifyAggregates(t, a, a.tree.Root)
+	})
+
+	t.Run("test against brute force", func(t *testing.T) {
+		random := rand.New(rand.NewSource(42))
+		sums := NewAugmentedTree(sumMonoid, func(key int, value int) int { return value })
+		maxes := NewAugmentedTree(maxMonoid, func(key int, value int) int { return value })
+		concat := NewAugmentedTree(concatMonoid, func(key int, value int) string { return string(rune('a' + key%26)) })
+		reference := map[int]int{}
+
+		for i := 0; i < 2000; i++ {
+			key := random.Intn(300)
+			if random.Intn(3) == 0 {
+				sums.Delete(key)
+				maxes.Delete(key)
+				concat.Delete(key)
+				delete(reference, key)
+			} else {
+				value := random.Intn(1000) - 500
+				sums.Insert(key, value)
+				maxes.Insert(key, value)
+				concat.Insert(key, value)
+				reference[key] = value
+			}
+
+			if i%100 != 0 {
+				continue
+			}
+
+			verifyAggregates(t, sums, sums.tree.Root)
+			verifyAggregates(t, concat, concat.tree.Root)
+			verifyRedBlackProperties(t, sums.tree)
+
+			for j := 0; j < 20; j++ {
+				lo := random.Intn(320) - 10
+				hi := lo + random.Intn(100)
+
+				expectedSum, expectedMax := 0, math.MinInt
+				var expectedConcat strings.Builder
+				for key := lo; key <= hi; key++ {
+					if value, ok := reference[key]; ok {
+						expectedSum += value
+						expectedMax = max(expectedMax, value)
+						expectedConcat.WriteRune(rune('a' + key%26))
+					}
+				}
+
+				if sum := sums.Aggregate(lo, hi); sum != expectedSum {
+					t.Errorf("Sum of [%d, %d]: expected %d, got %d", lo, hi, expectedSum, sum)
+				}
+
+				if maximum := maxes.Aggregate(lo, hi); maximum != expectedMax {
+					t.Errorf("Max of [%d, %d]: expected %d, got %d", lo, hi, expectedMax, maximum)
+				}
+
+				if text := concat.Aggregate(lo, hi); text != expectedConcat.String() {
+					t.Errorf("Concat of [%d, %d]: expected %q, got %q", lo, hi, expectedConcat.String(), text)
+				}
+			}
+		}
+
+		if sums.Size() != len(reference) {
+			t.Errorf("Expected size %d, got %d", len(reference), sums.Size())
+		}
+	})
+
+	t.Run("test search and iteration", func(t *testing.T) {
+		a := NewAugmentedTree(sumMonoid, func(key string, value int) int { return value })
+		a.Insert("b", 2)
+		a.Insert("a", 1)
+
+		if value, found := a.Search("b"); !found || value != 2 {
+			t.Errorf("Expected value 2, got %d", value)
+		}
+
+		if _, found := a.Search("c"); found {
+			t.Error("Should not find missing key")
+		}
+
+		keys := ""
+		for key := range a.ForwardIterator() {
+			keys += key
+		}
+
+		if keys != "ab" {
+			t.Errorf("Expected keys in order 'ab', got %q", keys)
+		}
+
+		a.Clear()
+		if !a.IsEmpty() || a.AggregateAll() != 0 {
+			t.Error("Tree should be empty after Clear")
+		}
+	})
+}
@@ -0,0 +1,569 @@
+package trees
+
+/*
+	1. AVL tree is a self balancing binary search tree.
+	2. For every node, heights of the left and right subtrees differ by at most one.
+	3. It is more strictly balanced than a red black tree, so lookups are faster,
+	   but insertions and deletions need more rotations.
+*/
+
+import (
+	"cmp"
+	"iter"
+)
+
+type AVLTreeNode[T any, V any] struct {
+	Key    T
+	Value  V
+	Left   *AVLTreeNode[T, V]
+	Right  *AVLTreeNode[T, V]
+	Parent *AVLTreeNode[T, V]
+	// height of the subtree rooted at this node, a leaf has height 1
+	height int
+}
+
+type AVLTree[T any, V any] struct {
+	Root     *AVLTreeNode[T, V]
+	treeSize int
+	compare  func(a, b T) int
+}
+
+// AVLCursor points at a single node of an AVLTree, same as Cursor does for a RedBlackTree.
+// A cursor which has moved past either end of the tree is no longer valid.
+type AVLCursor[T any, V any] struct {
+	current *AVLTreeNode[T, V]
+	tree    *AVLTree[T, V]
+}
+
+// Returns a pointer to an instance of an AVLTree struct.
+// Works with default built in types.
+func NewAVLTree[T cmp.Ordered, V any]() *AVLTree[T, V] {
+	return NewAVLTreeWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an instance of an AVLTree struct.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewAVLTreeWithFunc[T any, V any](comparator func(a, b T) int) *AVLTree[T, V] {
+	return &AVLTree[T, V]{
+		Root:     nil,
+		treeSize: 0,
+		compare:  comparator,
+	}
+}
+
+// Inserts a key-value pair into the AVLTree.
+// If the key already exists, it's value is updated.
+func (t *AVLTree[T, V]) Insert(key T, value V) {
+	var parentNode *AVLTreeNode[T, V]
+	currentNode := t.Root
+	result := 0
+
+	for currentNode != nil {
+		parentNode = currentNode
+		result = t.compare(key, currentNode.Key)
+		if result < 0 {
+			currentNode = currentNode.Left
+		} else if result > 0 {
+			currentNode = currentNode.Right
+		} else {
+			// exact key found, update the value, nothing left to do
+			currentNode.Value = value
+			return
+		}
+	}
+
+	newNode := &AVLTreeNode[T, V]{
+		Key:    key,
+		Value:  value,
+		Parent: parentNode,
+		height: 1,
+	}
+
+	if parentNode == nil {
+		t.Root = newNode
+	} else if result < 0 {
+		parentNode.Left = newNode
+	} else {
+		parentNode.Right = newNode
+	}
+
+	t.rebalanceUp(parentNode)
+	t.treeSize++
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (t *AVLTree[T, V]) Delete(key T) bool {
+	node, ok := t.Search(key)
+	if !ok {
+		return false
+	}
+
+	t.deleteNode(node)
+	return true
+}
+
+// Searches for a key in the tree.
+// Returns the node and boolean value.
+// Boolean is true if key is found, otherwise false.
+func (t *AVLTree[T, V]) Search(key T) (*AVLTreeNode[T, V], bool) {
+	currentNode := t.Root
+
+	for currentNode != nil {
+		result := t.compare(key, currentNode.Key)
+		if result == 0 {
+			return currentNode, true
+		} else if result < 0 {
+			currentNode = currentNode.Left
+		} else {
+			currentNode = currentNode.Right
+		}
+	}
+
+	return nil, false
+}
+
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (t *AVLTree[T, V]) Get(key T) (V, bool) {
+	node, ok := t.Search(key)
+	if !ok {
+		return *new(V), false
+	}
+	return node.Value, true
+}
+
+// Returns true if tree is empty, otherwise false.
+func (t *AVLTree[T, V]) IsEmpty() bool {
+	return t.Root == nil
+}
+
+// Returns the current number of nodes in the tree.
+func (t *AVLTree[T, V]) Size() int {
+	return t.treeSize
+}
+
+// Clears and resets the tree to an empty tree.
+func (t *AVLTree[T, V]) Clear() {
+	t.Root = nil
+	t.treeSize = 0
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (t *AVLTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		for node := t.minimum(t.Root); node != nil; node = t.inorderSuccessor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (t *AVLTree[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		for node := t.maximum(t.Root); node != nil; node = t.inorderPredecessor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.Range.
+func (t *AVLTree[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		var start *AVLCursor[T, V]
+		if excludeLow {
+			start, _ = t.Higher(lo)
+		} else {
+			start, _ = t.Ceiling(lo)
+		}
+
+		for node := start.current; node != nil; node = t.inorderSuccessor(node) {
+			result := t.compare(node.Key, hi)
+			if result > 0 || (result == 0 && excludeHigh) {
+				return
+			}
+
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi in descending order.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.RangeDesc.
+func (t *AVLTree[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		var start *AVLCursor[T, V]
+		if excludeHigh {
+			start, _ = t.Lower(hi)
+		} else {
+			start, _ = t.Floor(hi)
+		}
+
+		for node := start.current; node != nil; node = t.inorderPredecessor(node) {
+			result := t.compare(node.Key, lo)
+			if result < 0 || (result == 0 && excludeLow) {
+				return
+			}
+
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a cursor positioned at the smallest key in the tree.
+// The cursor is not valid if the tree is empty.
+func (t *AVLTree[T, V]) Begin() *AVLCursor[T, V] {
+	return &AVLCursor[T, V]{current: t.minimum(t.Root), tree: t}
+}
+
+// Returns a cursor positioned at the largest key in the tree.
+// The cursor is not valid if the tree is empty.
+func (t *AVLTree[T, V]) End() *AVLCursor[T, V] {
+	return &AVLCursor[T, V]{current: t.maximum(t.Root), tree: t}
+}
+
+// Returns a cursor positioned at the smallest key in the tree.
+// Boolean is false if the tree is empty.
+func (t *AVLTree[T, V]) Min() (*AVLCursor[T, V], bool) {
+	it := t.Begin()
+	return it, it.current != nil
+}
+
+// Returns a cursor positioned at the largest key in the tree.
+// Boolean is false if the tree is empty.
+func (t *AVLTree[T, V]) Max() (*AVLCursor[T, V], bool) {
+	it := t.End()
+	return it, it.current != nil
+}
+
+// Returns a cursor positioned at the largest key less than or equal to the given key.
+// Boolean is false if no such key exists.
+func (t *AVLTree[T, V]) Floor(key T) (*AVLCursor[T, V], bool) {
+	return t.bound(key, true, true)
+}
+
+// Returns a cursor positioned at the smallest key greater than or equal to the given key.
+// Boolean is false if no such key exists.
+func (t *AVLTree[T, V]) Ceiling(key T) (*AVLCursor[T, V], bool) {
+	return t.bound(key, false, true)
+}
+
+// Returns a cursor positioned at the largest key strictly less than the given key.
+// Boolean is false if no such key exists.
+func (t *AVLTree[T, V]) Lower(key T) (*AVLCursor[T, V], bool) {
+	return t.bound(key, true, false)
+}
+
+// Returns a cursor positioned at the smallest key strictly greater than the given key.
+// Boolean is false if no such key exists.
+func (t *AVLTree[T, V]) Higher(key T) (*AVLCursor[T, V], bool) {
+	return t.bound(key, false, false)
+}
+
+// Returns true if the cursor points at a node in the tree.
+func (c *AVLCursor[T, V]) Valid() bool {
+	return c.current != nil
+}
+
+// Moves the cursor to the smallest key greater than or equal to the given key.
+// Returns true if such key exists, otherwise the cursor becomes invalid.
+func (c *AVLCursor[T, V]) Seek(key T) bool {
+	found, _ := c.tree.Ceiling(key)
+	c.current = found.current
+	return c.Valid()
+}
+
+// Moves the cursor to the next key in sorted order.
+// Returns false if there is no next key, the cursor becomes invalid in that case.
+func (c *AVLCursor[T, V]) Next() bool {
+	if c.current == nil {
+		return false
+	}
+	c.current = c.tree.inorderSuccessor(c.current)
+	return c.Valid()
+}
+
+// Moves the cursor to the previous key in sorted order.
+// Returns false if there is no previous key, the cursor becomes invalid in that case.
+func (c *AVLCursor[T, V]) Prev() bool {
+	if c.current == nil {
+		return false
+	}
+	c.current = c.tree.inorderPredecessor(c.current)
+	return c.Valid()
+}
+
+// Returns the key-value pair the cursor points at.
+// Returns zero values if the cursor is not valid.
+func (c *AVLCursor[T, V]) Val() (T, V) {
+	if c.current == nil {
+		return *new(T), *new(V)
+	}
+	return c.current.Key, c.current.Value
+}
+
+// Returns the key the cursor points at.
+// Returns zero value if the cursor is not valid.
+func (c *AVLCursor[T, V]) Key() T {
+	key, _ := c.Val()
+	return key
+}
+
+// Returns the value the cursor points at.
+// Returns zero value if the cursor is not valid.
+func (c *AVLCursor[T, V]) Value() V {
+	_, value := c.Val()
+	return value
+}
+
+// Replaces the value of the node the cursor points at.
+// Returns false if the cursor is not valid.
+func (c *AVLCursor[T, V]) SetValue(value V) bool {
+	if c.current == nil {
+		return false
+	}
+	c.current.Value = value
+	return true
+}
+
+// Deletes the node the cursor points at and moves the cursor to the next key.
+// Returns false if the cursor was not valid, nothing is deleted in that case.
+// Other cursors pointing at the deleted node become invalid to use.
+func (c *AVLCursor[T, V]) Erase() bool {
+	if c.current == nil {
+		return false
+	}
+
+	// deletion re-links nodes instead of copying keys around,
+	// so the successor node stays the same after the current node is removed
+	next := c.tree.inorderSuccessor(c.current)
+	c.tree.deleteNode(c.current)
+	c.current = next
+	return true
+}
+
+// Removes the given node from the tree.
+// The node must belong to the tree.
+func (t *AVLTree[T, V]) deleteNode(node *AVLTreeNode[T, V]) {
+	var rebalanceFrom *AVLTreeNode[T, V]
+
+	if node.Left == nil {
+		rebalanceFrom = node.Parent
+		t.transplant(node, node.Right)
+	} else if node.Right == nil {
+		rebalanceFrom = node.Parent
+		t.transplant(node, node.Left)
+	} else {
+		// both children exist, the inorder successor takes the place of the node
+		successor := t.minimum(node.Right)
+		if successor.Parent == node {
+			rebalanceFrom = successor
+		} else {
+			rebalanceFrom = successor.Parent
+			t.transplant(successor, successor.Right)
+			successor.Right = node.Right
+			successor.Right.Parent = successor
+		}
+
+		t.transplant(node, successor)
+		successor.Left = node.Left
+		successor.Left.Parent = successor
+	}
+
+	t.rebalanceUp(rebalanceFrom)
+	t.treeSize--
+}
+
+// Returns a cursor at the closest key to the given key.
+// 'below' searches for keys smaller than the key, otherwise for greater keys.
+// 'inclusive' allows the key itself to be returned.
+func (t *AVLTree[T, V]) bound(key T, below, inclusive bool) (*AVLCursor[T, V], bool) {
+	var found *AVLTreeNode[T, V]
+	currentNode := t.Root
+
+	for currentNode != nil {
+		result := t.compare(key, currentNode.Key)
+		if result == 0 && inclusive {
+			found = currentNode
+			break
+		}
+
+		if below {
+			if result > 0 {
+				// current node is a candidate, look for a bigger one on the right
+				found = currentNode
+				currentNode = currentNode.Right
+			} else {
+				currentNode = currentNode.Left
+			}
+		} else {
+			if result < 0 {
+				// current node is a candidate, look for a smaller one on the left
+				found = currentNode
+				currentNode = currentNode.Left
+			} else {
+				currentNode = currentNode.Right
+			}
+		}
+	}
+
+	return &AVLCursor[T, V]{current: found, tree: t}, found != nil
+}
+
+// Walks from 'node' up to the root, fixing heights and rotating wherever the balance is off.
+func (t *AVLTree[T, V]) rebalanceUp(node *AVLTreeNode[T, V]) {
+	for node != nil {
+		t.updateHeight(node)
+		balance := t.height(node.Left) - t.height(node.Right)
+
+		if balance > 1 {
+			// left heavy
+			if t.height(node.Left.Left) < t.height(node.Left.Right) {
+				// left-right case, turn it into left-left first
+				t.rotateLeft(node.Left)
+			}
+			node = t.rotateRight(node)
+		} else if balance < -1 {
+			// right heavy
+			if t.height(node.Right.Right) < t.height(node.Right.Left) {
+				// right-left case, turn it into right-right first
+				t.rotateRight(node.Right)
+			}
+			node = t.rotateLeft(node)
+		}
+
+		node = node.Parent
+	}
+}
+
+// Rotates left around 'x' and returns the node which took it's place.
+func (t *AVLTree[T, V]) rotateLeft(x *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	y := x.Right
+
+	x.Right = y.Left
+	if y.Left != nil {
+		y.Left.Parent = x
+	}
+
+	t.transplant(x, y)
+	y.Left = x
+	x.Parent = y
+
+	t.updateHeight(x)
+	t.updateHeight(y)
+	return y
+}
+
+// Rotates right around 'x' and returns the node which took it's place.
+func (t *AVLTree[T, V]) rotateRight(x *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	y := x.Left
+
+	x.Left = y.Right
+	if y.Right != nil {
+		y.Right.Parent = x
+	}
+
+	t.transplant(x, y)
+	y.Right = x
+	x.Parent = y
+
+	t.updateHeight(x)
+	t.updateHeight(y)
+	return y
+}
+
+// Transplants subtree rooted at n with m, m can be nil.
+func (t *AVLTree[T, V]) transplant(n, m *AVLTreeNode[T, V]) {
+	if n.Parent == nil {
+		t.Root = m
+	} else if n == n.Parent.Left {
+		n.Parent.Left = m
+	} else {
+		n.Parent.Right = m
+	}
+
+	if m != nil {
+		m.Parent = n.Parent
+	}
+}
+
+func (t *AVLTree[T, V]) height(node *AVLTreeNode[T, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (t *AVLTree[T, V]) updateHeight(node *AVLTreeNode[T, V]) {
+	node.height = max(t.height(node.Left), t.height(node.Right)) + 1
+}
+
+// Returns minimum node in a subtree rooted at 'node'
+func (t *AVLTree[T, V]) minimum(node *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	if node == nil {
+		return nil
+	}
+	for node.Left != nil {
+		node = node.Left
+	}
+	return node
+}
+
+// Returns maximum node in a subtree rooted at 'node'
+func (t *AVLTree[T, V]) maximum(node *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	if node == nil {
+		return nil
+	}
+	for node.Right != nil {
+		node = node.Right
+	}
+	return node
+}
+
+// Returns inorder successor node for current node.
+func (t *AVLTree[T, V]) inorderSuccessor(node *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	if node.Right != nil {
+		return t.minimum(node.Right)
+	}
+
+	parent := node.Parent
+	for parent != nil && node == parent.Right {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
+
+// Returns inorder predecessor node for current node.
+func (t *AVLTree[T, V]) inorderPredecessor(node *AVLTreeNode[T, V]) *AVLTreeNode[T, V] {
+	if node.Left != nil {
+		return t.maximum(node.Left)
+	}
+
+	parent := node.Parent
+	for parent != nil && node == parent.Left {
+		node = parent
+		parent = parent.Parent
+	}
+	return parent
+}
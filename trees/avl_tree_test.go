@@ -0,0 +1,225 @@
+package trees
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Helper function to verify AVL tree properties
+func verifyAVLProperties[T any, V any](t *testing.T, tree *AVLTree[T, V]) {
+	if tree.Root != nil && tree.Root.Parent != nil {
+		t.Error("Property violation: Root must not have a parent")
+	}
+
+	if size := verifyAVLNode(t, tree, tree.Root); size != tree.Size() {
+		t.Errorf("Node count %d does not match tree size %d", size, tree.Size())
+	}
+}
+
+// Checks heights, balance factors, parent links and key ordering, returns the number of nodes in the subtree.
+func verifyAVLNode[T any, V any](t *testing.T, tree *AVLTree[T, V], node *AVLTreeNode[T, V]) int {
+	if node == nil {
+		return 0
+	}
+
+	if node.Left != nil {
+		if node.Left.Parent != node {
+			t.Errorf("Parent link broken at left child of %v", node.Key)
+		}
+		if tree.compare(node.Left.Key, node.Key) >= 0 {
+			t.Errorf("BST violation: left child %v of %v", node.Left.Key, node.Key)
+		}
+	}
+	if node.Right != nil {
+		if node.Right.Parent != node {
+			t.Errorf("Parent link broken at right child of %v", node.Key)
+		}
+		if tree.compare(node.Right.Key, node.Key) <= 0 {
+			t.Errorf("BST violation: right child %v of %v", node.Right.Key, node.Key)
+		}
+	}
+
+	leftHeight, rightHeight := tree.height(node.Left), tree.height(node.Right)
+	if node.height != max(leftHeight, rightHeight)+1 {
+		t.Errorf("Height mismatch at node %v (stored: %d, actual: %d)", node.Key, node.height, max(leftHeight, rightHeight)+1)
+	}
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		t.Errorf("Property violation: node %v is unbalanced (%d vs %d)", node.Key, leftHeight, rightHeight)
+	}
+
+	return verifyAVLNode(t, tree, node.Left) + verifyAVLNode(t, tree, node.Right) + 1
+}
+
+func TestAVLInsertSequential(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+
+	for i := range 1000 {
+		tree.Insert(i, i*10)
+	}
+	verifyAVLProperties(t, tree)
+
+	// 1000 keys fit in a perfectly balanced tree of height 10, AVL allows at most ~1.44x that
+	if tree.Root.height > 14 {
+		t.Errorf("Tree too tall for 1000 sequential keys: height %d", tree.Root.height)
+	}
+
+	for i := range 1000 {
+		value, ok := tree.Get(i)
+		if !ok || value != i*10 {
+			t.Errorf("Get(%d) = %d, %v, want %d, true", i, value, ok, i*10)
+		}
+	}
+}
+
+func TestAVLInsertDuplicate(t *testing.T) {
+	tree := NewAVLTree[string, int]()
+
+	tree.Insert("a", 1)
+	tree.Insert("a", 2)
+
+	if tree.Size() != 1 {
+		t.Errorf("Expected size 1, got %d", tree.Size())
+	}
+	if value, _ := tree.Get("a"); value != 2 {
+		t.Errorf("Expected updated value 2, got %d", value)
+	}
+}
+
+func TestAVLDelete(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	if tree.Delete(1) {
+		t.Error("Delete on empty tree should return false")
+	}
+
+	keys := rand.New(rand.NewSource(1)).Perm(2000)
+	for _, key := range keys {
+		tree.Insert(key, key)
+	}
+	verifyAVLProperties(t, tree)
+
+	for i, key := range keys {
+		if !tree.Delete(key) {
+			t.Fatalf("Delete(%d) returned false", key)
+		}
+		if tree.Delete(key) {
+			t.Fatalf("Second Delete(%d) returned true", key)
+		}
+		if i%100 == 0 {
+			verifyAVLProperties(t, tree)
+		}
+	}
+
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Errorf("Expected empty tree, got size %d", tree.Size())
+	}
+}
+
+func TestAVLCustomComparator(t *testing.T) {
+	// reverse ordering
+	tree := NewAVLTreeWithFunc[int, string](func(a, b int) int { return cmp.Compare(b, a) })
+	for _, key := range []int{3, 1, 4, 5, 9, 2, 6} {
+		tree.Insert(key, "")
+	}
+	verifyAVLProperties(t, tree)
+
+	var got []int
+	for key := range tree.ForwardIterator() {
+		got = append(got, key)
+	}
+	if want := []int{9, 6, 5, 4, 3, 2, 1}; !slices.Equal(got, want) {
+		t.Errorf("Got %v, want %v", got, want)
+	}
+}
+
+func TestAVLNavigation(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	if _, ok := tree.Min(); ok {
+		t.Error("Min on empty tree should return false")
+	}
+	if _, ok := tree.Floor(10); ok {
+		t.Error("Floor on empty tree should return false")
+	}
+
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(key, key)
+	}
+
+	tests := []struct {
+		name   string
+		find   func(int) (*AVLCursor[int, int], bool)
+		key    int
+		want   int
+		wantOk bool
+	}{
+		{"Floor exact", tree.Floor, 30, 30, true},
+		{"Floor between", tree.Floor, 35, 30, true},
+		{"Floor below all", tree.Floor, 5, 0, false},
+		{"Ceiling exact", tree.Ceiling, 30, 30, true},
+		{"Ceiling between", tree.Ceiling, 35, 40, true},
+		{"Ceiling above all", tree.Ceiling, 55, 0, false},
+		{"Lower exact", tree.Lower, 30, 20, true},
+		{"Lower smallest", tree.Lower, 10, 0, false},
+		{"Higher exact", tree.Higher, 30, 40, true},
+		{"Higher largest", tree.Higher, 50, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			it, ok := tt.find(tt.key)
+			if ok != tt.wantOk || it.Key() != tt.want {
+				t.Errorf("Got %d, %v, want %d, %v", it.Key(), ok, tt.want, tt.wantOk)
+			}
+		})
+	}
+
+	if it, ok := tree.Min(); !ok || it.Key() != 10 {
+		t.Errorf("Min = %d, %v, want 10, true", it.Key(), ok)
+	}
+	if it, ok := tree.Max(); !ok || it.Key() != 50 {
+		t.Errorf("Max = %d, %v, want 50, true", it.Key(), ok)
+	}
+}
+
+func TestAVLCursor(t *testing.T) {
+	tree := NewAVLTree[int, int]()
+	for i := range 10 {
+		tree.Insert(i, i)
+	}
+
+	// walk forward, erasing every even key
+	for it := tree.Begin(); it.Valid(); {
+		if it.Key()%2 == 0 {
+			it.Erase()
+		} else {
+			it.SetValue(it.Value() * 100)
+			it.Next()
+		}
+	}
+	verifyAVLProperties(t, tree)
+
+	var keys, values []int
+	for it := tree.End(); it.Valid(); it.Prev() {
+		key, value := it.Val()
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	if want := []int{9, 7, 5, 3, 1}; !slices.Equal(keys, want) {
+		t.Errorf("Got keys %v, want %v", keys, want)
+	}
+	if want := []int{900, 700, 500, 300, 100}; !slices.Equal(values, want) {
+		t.Errorf("Got values %v, want %v", values, want)
+	}
+
+	it := tree.Begin()
+	if !it.Seek(4) || it.Key() != 5 {
+		t.Errorf("Seek(4) should land on 5, got %d", it.Key())
+	}
+	if it.Seek(10) || it.Valid() {
+		t.Error("Seek past the end should invalidate the cursor")
+	}
+	if it.Next() || it.Prev() || it.SetValue(1) || it.Erase() {
+		t.Error("Invalid cursor operations should return false")
+	}
+}
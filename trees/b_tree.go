@@ -0,0 +1,580 @@
+package trees
+
+/*
+	1. B-tree is a self balancing search tree where every node holds many keys.
+	2. Each node other than the root holds between degree-1 and 2*degree-1 keys,
+	   an internal node with n keys has n+1 children.
+	3. All leaves are at the same depth.
+	4. Keys of a node are stored in contiguous slices, so a lookup touches a few
+	   cache friendly nodes instead of chasing one pointer per key.
+*/
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// Degree used when the requested degree is too small to form a B-tree.
+const DefaultBTreeDegree = 32
+
+type bTreeNode[T any, V any] struct {
+	keys     []T
+	values   []V
+	children []*bTreeNode[T, V]
+}
+
+type BTree[T any, V any] struct {
+	root     *bTreeNode[T, V]
+	degree   int
+	treeSize int
+	compare  func(a, b T) int
+}
+
+// Returns a pointer to an instance of a BTree struct.
+// Works with default built in types.
+// Degree is the minimum number of children of an internal node,
+// values less than 2 fall back to DefaultBTreeDegree.
+func NewBTree[T cmp.Ordered, V any](degree int) *BTree[T, V] {
+	return NewBTreeWithFunc[T, V](degree, cmp.Compare[T])
+}
+
+// Returns a pointer to an instance of a BTree struct.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewBTreeWithFunc[T any, V any](degree int, comparator func(a, b T) int) *BTree[T, V] {
+	if degree < 2 {
+		degree = DefaultBTreeDegree
+	}
+
+	return &BTree[T, V]{
+		root:     nil,
+		degree:   degree,
+		treeSize: 0,
+		compare:  comparator,
+	}
+}
+
+// Returns the degree of the tree.
+func (b *BTree[T, V]) Degree() int {
+	return b.degree
+}
+
+// Inserts a key-value pair into the BTree.
+// If the key already exists, it's value is updated.
+func (b *BTree[T, V]) Insert(key T, value V) {
+	if b.root == nil {
+		b.root = b.newNode(true)
+		b.root.keys = append(b.root.keys, key)
+		b.root.values = append(b.root.values, value)
+		b.treeSize++
+		return
+	}
+
+	// full nodes are split on the way down, so there is always room for the new key
+	if b.isFull(b.root) {
+		newRoot := b.newNode(false)
+		newRoot.children = append(newRoot.children, b.root)
+		b.splitChild(newRoot, 0)
+		b.root = newRoot
+	}
+
+	node := b.root
+	for {
+		i, found := b.find(node, key)
+		if found {
+			node.values[i] = value
+			return
+		}
+
+		if node.isLeaf() {
+			node.keys = slices.Insert(node.keys, i, key)
+			node.values = slices.Insert(node.values, i, value)
+			b.treeSize++
+			return
+		}
+
+		if b.isFull(node.children[i]) {
+			b.splitChild(node, i)
+			// the middle key of the child moved up to index i
+			result := b.compare(key, node.keys[i])
+			if result == 0 {
+				node.values[i] = value
+				return
+			} else if result > 0 {
+				i++
+			}
+		}
+		node = node.children[i]
+	}
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (b *BTree[T, V]) Delete(key T) bool {
+	if b.root == nil {
+		return false
+	}
+
+	deleted := b.delete(b.root, key)
+
+	// root lost it's last key, the tree shrinks by one level
+	if len(b.root.keys) == 0 {
+		if b.root.isLeaf() {
+			b.root = nil
+		} else {
+			b.root = b.root.children[0]
+		}
+	}
+
+	if deleted {
+		b.treeSize--
+	}
+	return deleted
+}
+
+// Searches for a key in the tree.
+// Returns the value and boolean value.
+// Boolean is true if key is found, otherwise false.
+func (b *BTree[T, V]) Search(key T) (V, bool) {
+	node := b.root
+
+	for node != nil {
+		i, found := b.find(node, key)
+		if found {
+			return node.values[i], true
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+
+	return *new(V), false
+}
+
+// Returns the value stored for the key, same as Search.
+func (b *BTree[T, V]) Get(key T) (V, bool) {
+	return b.Search(key)
+}
+
+// Returns true if tree is empty, otherwise false.
+func (b *BTree[T, V]) IsEmpty() bool {
+	return b.treeSize == 0
+}
+
+// Returns the current number of keys in the tree.
+func (b *BTree[T, V]) Size() int {
+	return b.treeSize
+}
+
+// Clears and resets the tree to an empty tree.
+func (b *BTree[T, V]) Clear() {
+	b.root = nil
+	b.treeSize = 0
+}
+
+// Returns the smallest key and it's value.
+// Boolean is false if the tree is empty.
+func (b *BTree[T, V]) Min() (T, V, bool) {
+	if b.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := b.root
+	for !node.isLeaf() {
+		node = node.children[0]
+	}
+	return node.keys[0], node.values[0], true
+}
+
+// Returns the largest key and it's value.
+// Boolean is false if the tree is empty.
+func (b *BTree[T, V]) Max() (T, V, bool) {
+	if b.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := b.root
+	for !node.isLeaf() {
+		node = node.children[len(node.children)-1]
+	}
+	last := len(node.keys) - 1
+	return node.keys[last], node.values[last], true
+}
+
+// Returns the largest key less than or equal to the given key and it's value.
+// Boolean is false if no such key exists.
+func (b *BTree[T, V]) Floor(key T) (T, V, bool) {
+	return b.bound(key, true, true)
+}
+
+// Returns the smallest key greater than or equal to the given key and it's value.
+// Boolean is false if no such key exists.
+func (b *BTree[T, V]) Ceiling(key T) (T, V, bool) {
+	return b.bound(key, false, true)
+}
+
+// Returns the largest key strictly less than the given key and it's value.
+// Boolean is false if no such key exists.
+func (b *BTree[T, V]) Lower(key T) (T, V, bool) {
+	return b.bound(key, true, false)
+}
+
+// Returns the smallest key strictly greater than the given key and it's value.
+// Boolean is false if no such key exists.
+func (b *BTree[T, V]) Higher(key T) (T, V, bool) {
+	return b.bound(key, false, false)
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (b *BTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		if b.root != nil {
+			b.ascend(b.root, yield)
+		}
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (b *BTree[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		if b.root != nil {
+			b.descend(b.root, yield)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.Range.
+func (b *BTree[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		if b.root != nil {
+			b.ascendRange(b.root, lo, hi, excludeLow, excludeHigh, yield)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi in descending order.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.RangeDesc.
+func (b *BTree[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		if b.root != nil {
+			b.descendRange(b.root, hi, lo, excludeHigh, excludeLow, yield)
+		}
+	}
+}
+
+// Removes key from the subtree rooted at 'node'.
+// Every node it descends into is first topped up to at least 'degree' keys,
+// so a key can always be taken out of it without further fixups going back up.
+func (b *BTree[T, V]) delete(node *bTreeNode[T, V], key T) bool {
+	i, found := b.find(node, key)
+
+	if node.isLeaf() {
+		if !found {
+			return false
+		}
+		node.keys = slices.Delete(node.keys, i, i+1)
+		node.values = slices.Delete(node.values, i, i+1)
+		return true
+	}
+
+	if found {
+		if left := node.children[i]; len(left.keys) >= b.degree {
+			// replace the key with it's predecessor, then delete the predecessor from the left subtree
+			predecessor := left
+			for !predecessor.isLeaf() {
+				predecessor = predecessor.children[len(predecessor.children)-1]
+			}
+			last := len(predecessor.keys) - 1
+			node.keys[i], node.values[i] = predecessor.keys[last], predecessor.values[last]
+			return b.delete(left, node.keys[i])
+		}
+
+		if right := node.children[i+1]; len(right.keys) >= b.degree {
+			// replace the key with it's successor, then delete the successor from the right subtree
+			successor := right
+			for !successor.isLeaf() {
+				successor = successor.children[0]
+			}
+			node.keys[i], node.values[i] = successor.keys[0], successor.values[0]
+			return b.delete(right, node.keys[i])
+		}
+
+		// both neighbours are minimal, merge them around the key and delete from the result
+		b.merge(node, i)
+		return b.delete(node.children[i], key)
+	}
+
+	if len(node.children[i].keys) < b.degree {
+		i = b.fill(node, i)
+	}
+	return b.delete(node.children[i], key)
+}
+
+// Makes sure child i of 'node' holds at least 'degree' keys,
+// by borrowing a key from a sibling or merging with one.
+// Returns the index of the child which now covers the keys of child i.
+func (b *BTree[T, V]) fill(node *bTreeNode[T, V], i int) int {
+	if i > 0 && len(node.children[i-1].keys) >= b.degree {
+		b.borrowFromLeft(node, i)
+		return i
+	}
+
+	if i < len(node.keys) && len(node.children[i+1].keys) >= b.degree {
+		b.borrowFromRight(node, i)
+		return i
+	}
+
+	if i < len(node.keys) {
+		b.merge(node, i)
+		return i
+	}
+
+	b.merge(node, i-1)
+	return i - 1
+}
+
+// Rotates the last key of child i-1 through the parent into child i.
+func (b *BTree[T, V]) borrowFromLeft(node *bTreeNode[T, V], i int) {
+	child, sibling := node.children[i], node.children[i-1]
+	last := len(sibling.keys) - 1
+
+	child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+	child.values = slices.Insert(child.values, 0, node.values[i-1])
+	node.keys[i-1], node.values[i-1] = sibling.keys[last], sibling.values[last]
+
+	sibling.keys = slices.Delete(sibling.keys, last, last+1)
+	sibling.values = slices.Delete(sibling.values, last, last+1)
+
+	if !sibling.isLeaf() {
+		lastChild := len(sibling.children) - 1
+		child.children = slices.Insert(child.children, 0, sibling.children[lastChild])
+		sibling.children = slices.Delete(sibling.children, lastChild, lastChild+1)
+	}
+}
+
+// Rotates the first key of child i+1 through the parent into child i.
+func (b *BTree[T, V]) borrowFromRight(node *bTreeNode[T, V], i int) {
+	child, sibling := node.children[i], node.children[i+1]
+
+	child.keys = append(child.keys, node.keys[i])
+	child.values = append(child.values, node.values[i])
+	node.keys[i], node.values[i] = sibling.keys[0], sibling.values[0]
+
+	sibling.keys = slices.Delete(sibling.keys, 0, 1)
+	sibling.values = slices.Delete(sibling.values, 0, 1)
+
+	if !sibling.isLeaf() {
+		child.children = append(child.children, sibling.children[0])
+		sibling.children = slices.Delete(sibling.children, 0, 1)
+	}
+}
+
+// Merges child i+1 and key i of 'node' into child i.
+func (b *BTree[T, V]) merge(node *bTreeNode[T, V], i int) {
+	child, sibling := node.children[i], node.children[i+1]
+
+	child.keys = append(append(child.keys, node.keys[i]), sibling.keys...)
+	child.values = append(append(child.values, node.values[i]), sibling.values...)
+	child.children = append(child.children, sibling.children...)
+
+	node.keys = slices.Delete(node.keys, i, i+1)
+	node.values = slices.Delete(node.values, i, i+1)
+	node.children = slices.Delete(node.children, i+1, i+2)
+}
+
+// Splits full child i of 'node' into two nodes, the middle key moves up into 'node'.
+func (b *BTree[T, V]) splitChild(node *bTreeNode[T, V], i int) {
+	child := node.children[i]
+	mid := b.degree - 1
+
+	sibling := b.newNode(child.isLeaf())
+	sibling.keys = append(sibling.keys, child.keys[mid+1:]...)
+	sibling.values = append(sibling.values, child.values[mid+1:]...)
+	if !child.isLeaf() {
+		sibling.children = append(sibling.children, child.children[mid+1:]...)
+		clear(child.children[mid+1:])
+		child.children = child.children[:mid+1]
+	}
+
+	node.keys = slices.Insert(node.keys, i, child.keys[mid])
+	node.values = slices.Insert(node.values, i, child.values[mid])
+	node.children = slices.Insert(node.children, i+1, sibling)
+
+	// zero out the moved entries so they do not keep keys and values alive
+	clear(child.keys[mid:])
+	clear(child.values[mid:])
+	child.keys = child.keys[:mid]
+	child.values = child.values[:mid]
+}
+
+// Returns the closest key to the given key.
+// 'below' searches for keys smaller than the key, otherwise for greater keys.
+// 'inclusive' allows the key itself to be returned.
+func (b *BTree[T, V]) bound(key T, below, inclusive bool) (T, V, bool) {
+	var found *bTreeNode[T, V]
+	foundIndex := 0
+	node := b.root
+
+	for node != nil {
+		i, exact := b.find(node, key)
+		if exact && inclusive {
+			return node.keys[i], node.values[i], true
+		}
+
+		// keys[i-1] < key <= keys[i], children[i] holds the keys in between
+		if below {
+			if i > 0 {
+				found, foundIndex = node, i-1
+			}
+		} else {
+			if exact {
+				// everything in children[i] is smaller than the key
+				i++
+			}
+			if i < len(node.keys) {
+				found, foundIndex = node, i
+			}
+		}
+
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+
+	if found == nil {
+		return *new(T), *new(V), false
+	}
+	return found.keys[foundIndex], found.values[foundIndex], true
+}
+
+// Yields all keys of the subtree in ascending order, returns false if iteration was stopped.
+func (b *BTree[T, V]) ascend(node *bTreeNode[T, V], yield func(T, V) bool) bool {
+	for i := range node.keys {
+		if !node.isLeaf() && !b.ascend(node.children[i], yield) {
+			return false
+		}
+		if !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+
+	if !node.isLeaf() {
+		return b.ascend(node.children[len(node.keys)], yield)
+	}
+	return true
+}
+
+// Yields all keys of the subtree in descending order, returns false if iteration was stopped.
+func (b *BTree[T, V]) descend(node *bTreeNode[T, V], yield func(T, V) bool) bool {
+	for i := len(node.keys) - 1; i >= 0; i-- {
+		if !node.isLeaf() && !b.descend(node.children[i+1], yield) {
+			return false
+		}
+		if !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+
+	if !node.isLeaf() {
+		return b.descend(node.children[0], yield)
+	}
+	return true
+}
+
+// Yields keys of the subtree between lo and hi in ascending order.
+// Returns false once the upper bound is passed or iteration was stopped.
+func (b *BTree[T, V]) ascendRange(node *bTreeNode[T, V], lo, hi T, excludeLow, excludeHigh bool, yield func(T, V) bool) bool {
+	// first index whose key is above lo, or equal to it when lo is included
+	start, exact := b.find(node, lo)
+	if exact && excludeLow {
+		start++
+	}
+
+	for i := start; i < len(node.keys); i++ {
+		if !node.isLeaf() && !b.ascendRange(node.children[i], lo, hi, excludeLow, excludeHigh, yield) {
+			return false
+		}
+
+		result := b.compare(node.keys[i], hi)
+		if result > 0 || (result == 0 && excludeHigh) {
+			return false
+		}
+		if !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+	}
+
+	if !node.isLeaf() {
+		return b.ascendRange(node.children[len(node.keys)], lo, hi, excludeLow, excludeHigh, yield)
+	}
+	return true
+}
+
+// Yields keys of the subtree between hi and lo in descending order.
+// Returns false once the lower bound is passed or iteration was stopped.
+func (b *BTree[T, V]) descendRange(node *bTreeNode[T, V], hi, lo T, excludeHigh, excludeLow bool, yield func(T, V) bool) bool {
+	// first index whose key is above hi, or equal to it when hi is excluded
+	end, exact := b.find(node, hi)
+	if exact && !excludeHigh {
+		end++
+	}
+
+	if !node.isLeaf() && !b.descendRange(node.children[end], hi, lo, excludeHigh, excludeLow, yield) {
+		return false
+	}
+
+	for i := end - 1; i >= 0; i-- {
+		result := b.compare(node.keys[i], lo)
+		if result < 0 || (result == 0 && excludeLow) {
+			return false
+		}
+		if !yield(node.keys[i], node.values[i]) {
+			return false
+		}
+		if !node.isLeaf() && !b.descendRange(node.children[i], hi, lo, excludeHigh, excludeLow, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the index of the first key in 'node' greater than or equal to the given key,
+// and whether that key is equal.
+func (b *BTree[T, V]) find(node *bTreeNode[T, V], key T) (int, bool) {
+	return slices.BinarySearchFunc(node.keys, key, b.compare)
+}
+
+func (b *BTree[T, V]) isFull(node *bTreeNode[T, V]) bool {
+	return len(node.keys) == 2*b.degree-1
+}
+
+// Allocates a node with room for the maximum number of keys,
+// so keys never have to be copied into a bigger slice.
+func (b *BTree[T, V]) newNode(leaf bool) *bTreeNode[T, V] {
+	node := &bTreeNode[T, V]{
+		keys:   make([]T, 0, 2*b.degree-1),
+		values: make([]V, 0, 2*b.degree-1),
+	}
+	if !leaf {
+		node.children = make([]*bTreeNode[T, V], 0, 2*b.degree)
+	}
+	return node
+}
+
+func (n *bTreeNode[T, V]) isLeaf() bool {
+	return n.children == nil
+}
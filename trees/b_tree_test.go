@@ -0,0 +1,356 @@
+package trees
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Helper function to verify B-tree properties
+func verifyBTreeProperties[T any, V any](t *testing.T, tree *BTree[T, V]) {
+	if tree.root == nil {
+		if tree.Size() != 0 {
+			t.Errorf("Empty root but tree size is %d", tree.Size())
+		}
+		return
+	}
+
+	leafDepth := -1
+	if count := verifyBTreeNode(t, tree, tree.root, 0, &leafDepth); count != tree.Size() {
+		t.Errorf("Key count %d does not match tree size %d", count, tree.Size())
+	}
+}
+
+// Checks key counts, key ordering and leaf depth, returns the number of keys in the subtree.
+func verifyBTreeNode[T any, V any](t *testing.T, tree *BTree[T, V], node *bTreeNode[T, V], depth int, leafDepth *int) int {
+	maxKeys := 2*tree.degree - 1
+	if len(node.keys) > maxKeys {
+		t.Errorf("Node at depth %d has %d keys, maximum is %d", depth, len(node.keys), maxKeys)
+	}
+	if node != tree.root && len(node.keys) < tree.degree-1 {
+		t.Errorf("Node at depth %d has %d keys, minimum is %d", depth, len(node.keys), tree.degree-1)
+	}
+	if node == tree.root && len(node.keys) == 0 {
+		t.Error("Root of a non empty tree has no keys")
+	}
+	if len(node.keys) != len(node.values) {
+		t.Errorf("Node at depth %d has %d keys but %d values", depth, len(node.keys), len(node.values))
+	}
+	for i := 1; i < len(node.keys); i++ {
+		if tree.compare(node.keys[i-1], node.keys[i]) >= 0 {
+			t.Errorf("Keys out of order at depth %d: %v", depth, node.keys)
+		}
+	}
+
+	if node.isLeaf() {
+		if *leafDepth == -1 {
+			*leafDepth = depth
+		} else if *leafDepth != depth {
+			t.Errorf("Leaves at different depths %d and %d", *leafDepth, depth)
+		}
+		return len(node.keys)
+	}
+
+	if len(node.children) != len(node.keys)+1 {
+		t.Errorf("Node at depth %d has %d keys but %d children", depth, len(node.keys), len(node.children))
+		return len(node.keys)
+	}
+
+	count := len(node.keys)
+	for i, child := range node.children {
+		// every key of child i lies between keys i-1 and i of the parent
+		if i > 0 && tree.compare(child.keys[0], node.keys[i-1]) <= 0 {
+			t.Errorf("Child %d at depth %d has key %v not above separator %v", i, depth, child.keys[0], node.keys[i-1])
+		}
+		if i < len(node.keys) && tree.compare(child.keys[len(child.keys)-1], node.keys[i]) >= 0 {
+			t.Errorf("Child %d at depth %d has key %v not below separator %v", i, depth, child.keys[len(child.keys)-1], node.keys[i])
+		}
+		count += verifyBTreeNode(t, tree, child, depth+1, leafDepth)
+	}
+	return count
+}
+
+func TestNewBTree(t *testing.T) {
+	tree := NewBTree[int, string](4)
+	if tree.Degree() != 4 {
+		t.Errorf("Expected degree 4, got %d", tree.Degree())
+	}
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Error("New tree should be empty")
+	}
+	if _, ok := tree.Search(1); ok {
+		t.Error("Search on empty tree should return false")
+	}
+	if tree.Delete(1) {
+		t.Error("Delete on empty tree should return false")
+	}
+
+	for _, degree := range []int{-1, 0, 1} {
+		if got := NewBTree[int, int](degree).Degree(); got != DefaultBTreeDegree {
+			t.Errorf("Degree %d should fall back to %d, got %d", degree, DefaultBTreeDegree, got)
+		}
+	}
+}
+
+func TestBTreeInsertDelete(t *testing.T) {
+	for _, degree := range []int{2, 3, 4, 16} {
+		t.Run(fmt.Sprintf("degree %d", degree), func(t *testing.T) {
+			tree := NewBTree[int, int](degree)
+			reference := map[int]int{}
+			r := rand.New(rand.NewSource(int64(degree)))
+
+			for i := range 5000 {
+				key := r.Intn(1000)
+				if r.Intn(2) == 0 {
+					_, exists := reference[key]
+					if tree.Delete(key) != exists {
+						t.Fatalf("Delete(%d) disagrees with reference", key)
+					}
+					delete(reference, key)
+				} else {
+					tree.Insert(key, i)
+					reference[key] = i
+				}
+
+				if i%250 == 0 {
+					verifyBTreeProperties(t, tree)
+				}
+			}
+			verifyBTreeProperties(t, tree)
+
+			if tree.Size() != len(reference) {
+				t.Fatalf("Size = %d, want %d", tree.Size(), len(reference))
+			}
+			for key, want := range reference {
+				if got, ok := tree.Search(key); !ok || got != want {
+					t.Fatalf("Search(%d) = %d, %v, want %d, true", key, got, ok, want)
+				}
+			}
+
+			// drain the tree completely
+			for key := range reference {
+				if !tree.Delete(key) {
+					t.Fatalf("Delete(%d) returned false", key)
+				}
+			}
+			verifyBTreeProperties(t, tree)
+			if !tree.IsEmpty() {
+				t.Errorf("Expected empty tree, got size %d", tree.Size())
+			}
+		})
+	}
+}
+
+func TestBTreeSequential(t *testing.T) {
+	tree := NewBTree[int, int](3)
+
+	for i := range 1000 {
+		tree.Insert(i, i)
+	}
+	verifyBTreeProperties(t, tree)
+
+	for i := 999; i >= 0; i -= 2 {
+		tree.Delete(i)
+	}
+	verifyBTreeProperties(t, tree)
+
+	var got []int
+	for key := range tree.ForwardIterator() {
+		got = append(got, key)
+	}
+	for i, key := range got {
+		if key != i*2 {
+			t.Fatalf("Expected only even keys in order, got %v at %d", key, i)
+		}
+	}
+	if len(got) != 500 {
+		t.Errorf("Expected 500 keys, got %d", len(got))
+	}
+}
+
+func TestBTreeNavigation(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Min on empty tree should return false")
+	}
+	if _, _, ok := tree.Max(); ok {
+		t.Error("Max on empty tree should return false")
+	}
+	if _, _, ok := tree.Floor(10); ok {
+		t.Error("Floor on empty tree should return false")
+	}
+
+	// multiples of 10, enough keys for a few levels with degree 2
+	for i := 1; i <= 50; i++ {
+		tree.Insert(i*10, i)
+	}
+
+	tests := []struct {
+		name   string
+		find   func(int) (int, int, bool)
+		key    int
+		want   int
+		wantOk bool
+	}{
+		{"Floor exact", tree.Floor, 300, 300, true},
+		{"Floor between", tree.Floor, 305, 300, true},
+		{"Floor below all", tree.Floor, 5, 0, false},
+		{"Floor above all", tree.Floor, 1000, 500, true},
+		{"Ceiling exact", tree.Ceiling, 300, 300, true},
+		{"Ceiling between", tree.Ceiling, 305, 310, true},
+		{"Ceiling below all", tree.Ceiling, 5, 10, true},
+		{"Ceiling above all", tree.Ceiling, 505, 0, false},
+		{"Lower exact", tree.Lower, 300, 290, true},
+		{"Lower smallest", tree.Lower, 10, 0, false},
+		{"Higher exact", tree.Higher, 300, 310, true},
+		{"Higher largest", tree.Higher, 500, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, value, ok := tt.find(tt.key)
+			if ok != tt.wantOk || key != tt.want {
+				t.Errorf("Got %d, %v, want %d, %v", key, ok, tt.want, tt.wantOk)
+			}
+			if ok && value != key/10 {
+				t.Errorf("Got value %d for key %d", value, key)
+			}
+		})
+	}
+
+	// every key as exact and in-between probe against a linear scan
+	for probe := 0; probe <= 510; probe++ {
+		wantFloor, wantHigher := -1, -1
+		for i := 1; i <= 50; i++ {
+			if i*10 <= probe {
+				wantFloor = i * 10
+			}
+			if i*10 > probe && wantHigher == -1 {
+				wantHigher = i * 10
+			}
+		}
+		if key, _, ok := tree.Floor(probe); (ok && key != wantFloor) || (!ok && wantFloor != -1) {
+			t.Fatalf("Floor(%d) = %d, %v, want %d", probe, key, ok, wantFloor)
+		}
+		if key, _, ok := tree.Higher(probe); (ok && key != wantHigher) || (!ok && wantHigher != -1) {
+			t.Fatalf("Higher(%d) = %d, %v, want %d", probe, key, ok, wantHigher)
+		}
+	}
+
+	if key, _, _ := tree.Min(); key != 10 {
+		t.Errorf("Min = %d, want 10", key)
+	}
+	if key, _, _ := tree.Max(); key != 500 {
+		t.Errorf("Max = %d, want 500", key)
+	}
+}
+
+func TestBTreeRange(t *testing.T) {
+	tree := NewBTree[int, int](2)
+	for i := range 100 {
+		tree.Insert(i, i)
+	}
+
+	collect := func(seq func(func(int, int) bool)) []int {
+		var keys []int
+		for key := range seq {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+	span := func(from, to, step int) []int {
+		var keys []int
+		for i := from; i != to+step; i += step {
+			keys = append(keys, i)
+		}
+		return keys
+	}
+
+	tests := []struct {
+		name string
+		got  []int
+		want []int
+	}{
+		{"closed", collect(tree.Range(20, 30)), span(20, 30, 1)},
+		{"exclude low", collect(tree.Range(20, 30, ExcludeLow)), span(21, 30, 1)},
+		{"exclude high", collect(tree.Range(20, 30, ExcludeHigh)), span(20, 29, 1)},
+		{"open", collect(tree.Range(20, 30, ExcludeLow, ExcludeHigh)), span(21, 29, 1)},
+		{"past the end", collect(tree.Range(95, 200)), span(95, 99, 1)},
+		{"empty", collect(tree.Range(50, 10)), nil},
+		{"desc closed", collect(tree.RangeDesc(30, 20)), span(30, 20, -1)},
+		{"desc exclude high", collect(tree.RangeDesc(30, 20, ExcludeHigh)), span(29, 20, -1)},
+		{"desc exclude low", collect(tree.RangeDesc(30, 20, ExcludeLow)), span(30, 21, -1)},
+		{"desc before the start", collect(tree.RangeDesc(4, -10)), span(4, 0, -1)},
+		{"backward", collect(tree.BackwardIterator()), span(99, 0, -1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !slices.Equal(tt.got, tt.want) {
+				t.Errorf("Got %v, want %v", tt.got, tt.want)
+			}
+		})
+	}
+
+	// early break stops the iteration
+	var keys []int
+	for key := range tree.Range(10, 90) {
+		if key == 13 {
+			break
+		}
+		keys = append(keys, key)
+	}
+	if !slices.Equal(keys, []int{10, 11, 12}) {
+		t.Errorf("Expected iteration to stop at 13, got %v", keys)
+	}
+}
+
+func TestBTreeClear(t *testing.T) {
+	tree := NewBTree[string, int](2)
+	for i, key := range []string{"a", "b", "c", "d", "e"} {
+		tree.Insert(key, i)
+	}
+	tree.Clear()
+
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Errorf("Expected empty tree after Clear, got size %d", tree.Size())
+	}
+	tree.Insert("z", 1)
+	if value, ok := tree.Search("z"); !ok || value != 1 {
+		t.Error("Tree should be usable after Clear")
+	}
+	verifyBTreeProperties(t, tree)
+}
+
+func BenchmarkBTreeDegreeInsert(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, degree := range []int{2, 8, 32, 128} {
+		b.Run(fmt.Sprintf("degree %d", degree), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				tree := NewBTree[int, int](degree)
+				for _, key := range keys {
+					tree.Insert(key, key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBTreeDegreeSearch(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, degree := range []int{2, 8, 32, 128} {
+		tree := NewBTree[int, int](degree)
+		for _, key := range keys {
+			tree.Insert(key, key)
+		}
+		b.Run(fmt.Sprintf("degree %d", degree), func(b *testing.B) {
+			for b.Loop() {
+				for _, key := range keys {
+					tree.Search(key)
+				}
+			}
+		})
+	}
+}
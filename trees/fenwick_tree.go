@@ -0,0 +1,215 @@
+package trees
+
+/*
+	1. Fenwick tree (binary indexed tree) keeps partial sums of an array in another array of the same size.
+	2. Entry i holds the sum of the lowbit(i) values ending at i, where lowbit is the lowest set bit,
+	   so any prefix is the sum of at most log n entries.
+	3. Point updates and prefix sums both take O(log n) time with very little memory and no pointers.
+*/
+
+// Number is the set of types a FenwickTree can sum.
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// FenwickTree keeps prefix sums of an array of numbers under point updates.
+// Indexes start from 0 and ranges are half open, same as SegmentTree.
+type FenwickTree[T Number] struct {
+	// 1 based, entry 0 is unused
+	sums []T
+}
+
+// FenwickTree2D keeps rectangle sums of a grid of numbers under point updates.
+// Rows and columns start from 0 and ranges are half open, same as FenwickTree.
+type FenwickTree2D[T Number] struct {
+	// 1 based in both dimensions, row 0 and column 0 are unused
+	sums [][]T
+	rows int
+	cols int
+}
+
+// Returns a pointer to a FenwickTree over 'size' zero values.
+func NewFenwickTree[T Number](size int) *FenwickTree[T] {
+	return &FenwickTree[T]{
+		sums: make([]T, max(size, 0)+1),
+	}
+}
+
+// Returns a pointer to a FenwickTree over the given values.
+// Runs in O(n) time, faster than adding the values one by one.
+func NewFenwickTreeFromSlice[T Number](values []T) *FenwickTree[T] {
+	f := NewFenwickTree[T](len(values))
+	copy(f.sums[1:], values)
+
+	// every entry passes it's partial sum on to the next entry covering it
+	for i := 1; i < len(f.sums); i++ {
+		if parent := i + lowbit(i); parent < len(f.sums) {
+			f.sums[parent] += f.sums[i]
+		}
+	}
+	return f
+}
+
+// Adds delta to the value at index i.
+// Returns false if the index is out of range.
+func (f *FenwickTree[T]) Add(i int, delta T) bool {
+	if i < 0 || i >= f.Size() {
+		return false
+	}
+
+	for i++; i < len(f.sums); i += lowbit(i) {
+		f.sums[i] += delta
+	}
+	return true
+}
+
+// Returns the sum of the first i values, indexes [0, i).
+// Boolean is false if i is out of range.
+func (f *FenwickTree[T]) PrefixSum(i int) (T, bool) {
+	if i < 0 || i > f.Size() {
+		return 0, false
+	}
+
+	var sum T
+	for ; i > 0; i -= lowbit(i) {
+		sum += f.sums[i]
+	}
+	return sum, true
+}
+
+// Returns the sum of the values with indexes in [l, r).
+// Boolean is false if the range is out of bounds or l is greater than r.
+func (f *FenwickTree[T]) RangeSum(l, r int) (T, bool) {
+	if l < 0 || r > f.Size() || l > r {
+		return 0, false
+	}
+
+	right, _ := f.PrefixSum(r)
+	left, _ := f.PrefixSum(l)
+	return right - left, true
+}
+
+// Returns the value at index i.
+// Boolean is false if the index is out of range.
+func (f *FenwickTree[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= f.Size() {
+		return 0, false
+	}
+	return f.RangeSum(i, i+1)
+}
+
+// Returns the smallest index i such that the sum of values [0, i] is at least target,
+// the lower bound of target among the prefix sums.
+// All values must be non negative, otherwise the prefix sums are not sorted.
+// Boolean is false if the sum of all values is less than target.
+// Runs in O(log n) time.
+func (f *FenwickTree[T]) FindByPrefix(target T) (int, bool) {
+	if f.Size() == 0 {
+		return 0, false
+	}
+
+	// walk down from the highest power of two, taking every entry which keeps the sum below target
+	position := 0
+	var sum T
+	step := 1
+	for step*2 < len(f.sums) {
+		step *= 2
+	}
+
+	for ; step > 0; step /= 2 {
+		next := position + step
+		if next < len(f.sums) && sum+f.sums[next] < target {
+			position = next
+			sum += f.sums[next]
+		}
+	}
+
+	// position is the longest prefix with a sum below target, the answer is the value right after it
+	if position >= f.Size() {
+		return 0, false
+	}
+	return position, true
+}
+
+// Returns the number of values in the tree.
+func (f *FenwickTree[T]) Size() int {
+	return len(f.sums) - 1
+}
+
+// Returns a pointer to a FenwickTree2D over a grid of zero values.
+func NewFenwickTree2D[T Number](rows, cols int) *FenwickTree2D[T] {
+	rows, cols = max(rows, 0), max(cols, 0)
+	sums := make([][]T, rows+1)
+	for i := range sums {
+		sums[i] = make([]T, cols+1)
+	}
+
+	return &FenwickTree2D[T]{
+		sums: sums,
+		rows: rows,
+		cols: cols,
+	}
+}
+
+// Adds delta to the value at the given cell.
+// Returns false if the cell is out of range.
+func (f *FenwickTree2D[T]) Add(row, col int, delta T) bool {
+	if row < 0 || row >= f.rows || col < 0 || col >= f.cols {
+		return false
+	}
+
+	for i := row + 1; i <= f.rows; i += lowbit(i) {
+		for j := col + 1; j <= f.cols; j += lowbit(j) {
+			f.sums[i][j] += delta
+		}
+	}
+	return true
+}
+
+// Returns the sum of the values in rows [0, row) and columns [0, col).
+// Boolean is false if the cell is out of range.
+func (f *FenwickTree2D[T]) PrefixSum(row, col int) (T, bool) {
+	if row < 0 || row > f.rows || col < 0 || col > f.cols {
+		return 0, false
+	}
+
+	var sum T
+	for i := row; i > 0; i -= lowbit(i) {
+		for j := col; j > 0; j -= lowbit(j) {
+			sum += f.sums[i][j]
+		}
+	}
+	return sum, true
+}
+
+// Returns the sum of the values in rows [row1, row2) and columns [col1, col2).
+// Boolean is false if the rectangle is out of bounds or inverted.
+func (f *FenwickTree2D[T]) RangeSum(row1, col1, row2, col2 int) (T, bool) {
+	if row1 < 0 || col1 < 0 || row2 > f.rows || col2 > f.cols || row1 > row2 || col1 > col2 {
+		return 0, false
+	}
+
+	// inclusion-exclusion over the four prefix rectangles
+	all, _ := f.PrefixSum(row2, col2)
+	above, _ := f.PrefixSum(row1, col2)
+	left, _ := f.PrefixSum(row2, col1)
+	corner, _ := f.PrefixSum(row1, col1)
+	return all - above - left + corner, true
+}
+
+// Returns the number of rows of the grid.
+func (f *FenwickTree2D[T]) Rows() int {
+	return f.rows
+}
+
+// Returns the number of columns of the grid.
+func (f *FenwickTree2D[T]) Cols() int {
+	return f.cols
+}
+
+// Returns the lowest set bit of i.
+func lowbit(i int) int {
+	return i & -i
+}
@@ -0,0 +1,138 @@
+package trees
+
+import (
+	"math/rand"
+	"testing"
+)
+
+// Checks the Fenwick tree against a plain slice doing the same operations
+func TestFenwickTreeBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(4))
+
+	for _, size := range []int{1, 2, 13, 64, 100} {
+		reference := make([]int, size)
+		for i := range reference {
+			reference[i] = r.Intn(10)
+		}
+		tree := NewFenwickTreeFromSlice(reference)
+
+		for range 1000 {
+			i := r.Intn(size)
+			delta := r.Intn(10)
+			tree.Add(i, delta)
+			reference[i] += delta
+
+			l := r.Intn(size + 1)
+			end := l + r.Intn(size-l+1)
+			want := 0
+			for _, value := range reference[l:end] {
+				want += value
+			}
+			if got, ok := tree.RangeSum(l, end); !ok || got != want {
+				t.Fatalf("Size %d: RangeSum(%d, %d) = %d, %v, want %d", size, l, end, got, ok, want)
+			}
+		}
+
+		prefix := 0
+		for i, value := range reference {
+			if got, _ := tree.PrefixSum(i); got != prefix {
+				t.Fatalf("Size %d: PrefixSum(%d) = %d, want %d", size, i, got, prefix)
+			}
+			if got, _ := tree.Get(i); got != value {
+				t.Fatalf("Size %d: Get(%d) = %d, want %d", size, i, got, value)
+			}
+			prefix += value
+		}
+
+		// lower bound over every reachable target
+		for target := 0; target <= prefix+1; target++ {
+			want, sum := size, 0
+			for i, value := range reference {
+				sum += value
+				if sum >= target {
+					want = i
+					break
+				}
+			}
+			got, ok := tree.FindByPrefix(target)
+			if ok != (want < size) || (ok && got != want) {
+				t.Fatalf("Size %d: FindByPrefix(%d) = %d, %v, want %d", size, target, got, ok, want)
+			}
+		}
+	}
+}
+
+func TestFenwickTreeBounds(t *testing.T) {
+	empty := NewFenwickTree[int](0)
+	if _, ok := empty.FindByPrefix(1); ok {
+		t.Error("FindByPrefix on empty tree should return false")
+	}
+	if sum, ok := empty.PrefixSum(0); !ok || sum != 0 {
+		t.Errorf("Empty prefix should be 0, got %d, %v", sum, ok)
+	}
+
+	tree := NewFenwickTree[float64](4)
+	if tree.Size() != 4 {
+		t.Errorf("Expected size 4, got %d", tree.Size())
+	}
+	if tree.Add(4, 1) || tree.Add(-1, 1) {
+		t.Error("Add out of range should return false")
+	}
+	if _, ok := tree.PrefixSum(5); ok {
+		t.Error("PrefixSum past the end should return false")
+	}
+	if _, ok := tree.RangeSum(3, 2); ok {
+		t.Error("Inverted range should return false")
+	}
+	if _, ok := tree.Get(4); ok {
+		t.Error("Get out of range should return false")
+	}
+
+	tree.Add(1, 0.5)
+	tree.Add(3, 1.25)
+	if sum, _ := tree.RangeSum(0, 4); sum != 1.75 {
+		t.Errorf("Expected sum 1.75, got %v", sum)
+	}
+	if i, ok := tree.FindByPrefix(1); !ok || i != 3 {
+		t.Errorf("FindByPrefix(1) = %d, %v, want 3, true", i, ok)
+	}
+}
+
+func TestFenwickTree2D(t *testing.T) {
+	r := rand.New(rand.NewSource(5))
+	const rows, cols = 17, 9
+
+	var reference [rows][cols]int
+	tree := NewFenwickTree2D[int](rows, cols)
+	if tree.Rows() != rows || tree.Cols() != cols {
+		t.Fatalf("Expected %dx%d grid, got %dx%d", rows, cols, tree.Rows(), tree.Cols())
+	}
+
+	for range 2000 {
+		row, col, delta := r.Intn(rows), r.Intn(cols), r.Intn(21)-10
+		tree.Add(row, col, delta)
+		reference[row][col] += delta
+
+		row1, col1 := r.Intn(rows+1), r.Intn(cols+1)
+		row2, col2 := row1+r.Intn(rows-row1+1), col1+r.Intn(cols-col1+1)
+		want := 0
+		for i := row1; i < row2; i++ {
+			for j := col1; j < col2; j++ {
+				want += reference[i][j]
+			}
+		}
+		if got, ok := tree.RangeSum(row1, col1, row2, col2); !ok || got != want {
+			t.Fatalf("RangeSum(%d, %d, %d, %d) = %d, %v, want %d", row1, col1, row2, col2, got, ok, want)
+		}
+	}
+
+	if tree.Add(rows, 0, 1) || tree.Add(0, -1, 1) {
+		t.Error("Add out of range should return false")
+	}
+	if _, ok := tree.PrefixSum(rows+1, 0); ok {
+		t.Error("PrefixSum out of range should return false")
+	}
+	if _, ok := tree.RangeSum(2, 0, 1, cols); ok {
+		t.Error("Inverted rectangle should return false")
+	}
+}
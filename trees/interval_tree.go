@@ -0,0 +1,209 @@
+package trees
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+)
+
+var ErrInvalidInterval = errors.New("interval start must be smaller than it's end")
+
+// Interval is a half-open interval [Start, End).
+type Interval[T any] struct {
+	Start T
+	End   T
+}
+
+// IntervalTree stores half-open intervals with a value attached to each, like reservations [start, end).
+// Intervals are ordered by start and then by end, every node also tracks the largest end point of it's subtree,
+// which lets overlap queries skip subtrees that end too early.
+// The largest end point is maintained by the red black tree itself through every rotation and fixup.
+// Intervals are not unique, values sharing the exact same interval are kept in insertion order, same as TreeMultiMap.
+type IntervalTree[T any, V any] struct {
+	tree    *RedBlackTree[Interval[T], intervalValue[T, V]]
+	compare func(a, b T) int
+	size    int
+}
+
+// Value stored in the underlying tree, the user values together with the largest end point of the node's subtree.
+type intervalValue[T any, V any] struct {
+	values []V
+	maxEnd T
+}
+
+// Returns a pointer to an empty IntervalTree.
+// Works with default built in types.
+func NewIntervalTree[T cmp.Ordered, V any]() *IntervalTree[T, V] {
+	return NewIntervalTreeWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an empty IntervalTree.
+// Works with any custom end point type as defined by the user.
+// Takes a comparator function that defines the ordering of the end points, same as NewRedBlackTreeWithFunc.
+func NewIntervalTreeWithFunc[T any, V any](comparator func(a, b T) int) *IntervalTree[T, V] {
+	it := &IntervalTree[T, V]{compare: comparator, size: 0}
+	it.tree = NewRedBlackTreeWithFunc[Interval[T], intervalValue[T, V]](func(a, b Interval[T]) int {
+		if result := comparator(a.Start, b.Start); result != 0 {
+			return result
+		}
+		return comparator(a.End, b.End)
+	})
+	it.tree.augment = it.updateMaxEnd
+	return it
+}
+
+// Inserts the interval [start, end) with the given value.
+// Existing values for the exact same interval are kept, the new value is placed after them.
+// Returns ErrInvalidInterval if start is not smaller than end.
+func (it *IntervalTree[T, V]) Insert(start, end T, value V) error {
+	if it.compare(start, end) >= 0 {
+		return fmt.Errorf("%w: [%v, %v)", ErrInvalidInterval, start, end)
+	}
+
+	key := Interval[T]{Start: start, End: end}
+	if node, ok := it.tree.Search(key); ok {
+		node.Value.values = append(node.Value.values, value)
+	} else {
+		it.tree.Insert(key, intervalValue[T, V]{values: []V{value}})
+	}
+	it.size++
+	return nil
+}
+
+// Removes the oldest value stored for the interval [start, end).
+// If the interval does not exist, returns false, otherwise true if deletion is successful.
+func (it *IntervalTree[T, V]) Delete(start, end T) bool {
+	node, ok := it.tree.Search(Interval[T]{Start: start, End: end})
+	if !ok {
+		return false
+	}
+
+	if len(node.Value.values) == 1 {
+		it.tree.deleteNode(node)
+	} else {
+		// clear the reference so the removed value can be garbage collected
+		node.Value.values[0] = *new(V)
+		node.Value.values = node.Value.values[1:]
+	}
+	it.size--
+	return true
+}
+
+// Removes every value stored for the interval [start, end).
+// Returns the number of values removed.
+func (it *IntervalTree[T, V]) DeleteAll(start, end T) int {
+	node, ok := it.tree.Search(Interval[T]{Start: start, End: end})
+	if !ok {
+		return 0
+	}
+
+	removed := len(node.Value.values)
+	it.tree.deleteNode(node)
+	it.size -= removed
+	return removed
+}
+
+// Returns all values stored for the exact interval [start, end) in insertion order.
+// Returns nil if the interval does not exist.
+// The returned slice is a copy and can be modified freely.
+func (it *IntervalTree[T, V]) Search(start, end T) []V {
+	node, ok := it.tree.Search(Interval[T]{Start: start, End: end})
+	if !ok {
+		return nil
+	}
+	return append([]V(nil), node.Value.values...)
+}
+
+// Returns a 'push' iterator over all intervals containing the point, i.e start <= point < end.
+// Works with 'for range' expression.
+// Intervals are returned in sorted order, values of the same interval in insertion order.
+func (it *IntervalTree[T, V]) Overlaps(point T) iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		it.overlaps(it.tree.Root, point, point, true, yield)
+	}
+}
+
+// Returns a 'push' iterator over all intervals overlapping the half-open range [lo, hi), i.e start < hi and end > lo.
+// Works with 'for range' expression.
+// Intervals are returned in sorted order, values of the same interval in insertion order.
+// An empty or inverted range, lo not smaller than hi, overlaps nothing, same as Insert rejecting it.
+func (it *IntervalTree[T, V]) OverlapsRange(lo, hi T) iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		if it.compare(lo, hi) >= 0 {
+			return
+		}
+		it.overlaps(it.tree.Root, lo, hi, false, yield)
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Intervals are returned in sorted order, by start and then by end, values of the same interval in insertion order.
+func (it *IntervalTree[T, V]) ForwardIterator() iter.Seq2[Interval[T], V] {
+	return func(yield func(Interval[T], V) bool) {
+		for interval, value := range it.tree.ForwardIterator() {
+			for _, v := range value.values {
+				if !yield(interval, v) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns the current number of values in the tree, counting every value of a repeated interval.
+func (it *IntervalTree[T, V]) Size() int {
+	return it.size
+}
+
+// Returns true if tree is empty, otherwise false.
+func (it *IntervalTree[T, V]) IsEmpty() bool {
+	return it.size == 0
+}
+
+// Clears and resets the tree to an empty tree.
+func (it *IntervalTree[T, V]) Clear() {
+	it.tree.Clear()
+	it.size = 0
+}
+
+// Yields every interval in the subtree rooted at 'node' which ends after lo and starts before hi,
+// or at hi when 'hiInclusive' is set. Returns false once yield asks to stop.
+func (it *IntervalTree[T, V]) overlaps(node *RedBlackTreeNode[Interval[T], intervalValue[T, V]], lo, hi T, hiInclusive bool, yield func(Interval[T], V) bool) bool {
+	// nothing in this subtree ends after lo
+	if node == it.tree.NIL || it.compare(node.Value.maxEnd, lo) <= 0 {
+		return true
+	}
+
+	if !it.overlaps(node.Left, lo, hi, hiInclusive, yield) {
+		return false
+	}
+
+	// this node and everything on it's right starts too late
+	result := it.compare(node.Key.Start, hi)
+	if result > 0 || (result == 0 && !hiInclusive) {
+		return true
+	}
+
+	if it.compare(node.Key.End, lo) > 0 {
+		for _, value := range node.Value.values {
+			if !yield(node.Key, value) {
+				return false
+			}
+		}
+	}
+
+	return it.overlaps(node.Right, lo, hi, hiInclusive, yield)
+}
+
+// Recomputes the largest end point of 'node' from it's children, called by the underlying tree.
+func (it *IntervalTree[T, V]) updateMaxEnd(node *RedBlackTreeNode[Interval[T], intervalValue[T, V]]) {
+	maxEnd := node.Key.End
+	for _, child := range []*RedBlackTreeNode[Interval[T], intervalValue[T, V]]{node.Left, node.Right} {
+		if child != it.tree.NIL && it.compare(child.Value.maxEnd, maxEnd) > 0 {
+			maxEnd = child.Value.maxEnd
+		}
+	}
+	node.Value.maxEnd = maxEnd
+}
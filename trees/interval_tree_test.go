@@ -0,0 +1,219 @@
+package trees
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func collectIntervals(seq iter.Seq2[Interval[int], string]) []string {
+	result := make([]string, 0)
+	for interval, value := range seq {
+		result = append(result, fmt.Sprintf("[%d,%d)=%s", interval.Start, interval.End, value))
+	}
+	return result
+}
+
+func TestIntervalTree(t *testing.T) {
+	t.Run("test overlap queries", func(t *testing.T) {
+		it := NewIntervalTree[int, string]()
+		reservations := []struct {
+			start, end int
+			name       string
+		}{
+			{9, 12, "a"},
+			{1, 5, "b"},
+			{3, 8, "c"},
+			{10, 11, "d"},
+			{15, 20, "e"},
+			{5, 9, "f"},
+		}
+
+		for _, r := range reservations {
+			if err := it.Insert(r.start, r.end, r.name); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		verifyRedBlackProperties(t, it.tree)
+
+		testCases := []struct {
+			name     string
+			seq      iter.Seq2[Interval[int], string]
+			expected []string
+		}{
+			{"point inside several", it.Overlaps(4), []string{"[1,5)=b", "[3,8)=c"}},
+			{"point at start is included", it.Overlaps(5), []string{"[3,8)=c", "[5,9)=f"}},
+			{"point at end is excluded", it.Overlaps(12), []string{}},
+			{"point in a gap", it.Overlaps(13), []string{}},
+			{"range", it.OverlapsRange(8, 10), []string{"[5,9)=f", "[9,12)=a"}},
+			{"range touching ends is excluded", it.OverlapsRange(12, 15), []string{}},
+			{"empty range overlaps nothing", it.OverlapsRange(5, 5), []string{}},
+			{"inverted range overlaps nothing", it.OverlapsRange(10, 4), []string{}},
+			{"range covering all", it.OverlapsRange(0, 100), []string{"[1,5)=b", "[3,8)=c", "[5,9)=f", "[9,12)=a", "[10,11)=d", "[15,20)=e"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				if result := collectIntervals(tc.seq); !slices.Equal(result, tc.expected) {
+					t.Errorf("Expected %v, got %v", tc.expected, result)
+				}
+			})
+		}
+	})
+
+	t.Run("test insert delete search", func(t *testing.T) {
+		it := NewIntervalTree[int, string]()
+		it.Insert(1, 10, "long")
+		it.Insert(2, 3, "short")
+
+		if err := it.Insert(5, 5, "empty"); !errors.Is(err, ErrInvalidInterval) {
+			t.Errorf("Expected ErrInvalidInterval, got %v", err)
+		}
+
+		if values := it.Search(2, 3); !slices.Equal(values, []string{"short"}) {
+			t.Errorf("Expected [short], got %v", values)
+		}
+		if values := it.Search(2, 4); values != nil {
+			t.Errorf("Expected nil for missing interval, got %v", values)
+		}
+
+		if !it.Delete(1, 10) || it.Delete(1, 10) {
+			t.Error("Delete should succeed exactly once")
+		}
+
+		// max end point must shrink after deleting the long interval
+		if result := collectIntervals(it.Overlaps(7)); len(result) != 0 {
+			t.Errorf("Expected no overlaps after delete, got %v", result)
+		}
+
+		if it.Size() != 1 || collectIntervals(it.ForwardIterator())[0] != "[2,3)=short" {
+			t.Error("Unexpected tree contents after delete")
+		}
+
+		it.Clear()
+		if !it.IsEmpty() {
+			t.Error("Tree should be empty after Clear")
+		}
+	})
+
+	t.Run("test same interval keeps every value", func(t *testing.T) {
+		it := NewIntervalTree[int, string]()
+		it.Insert(9, 10, "room a")
+		it.Insert(9, 10, "room b")
+		it.Insert(9, 10, "room c")
+		it.Insert(8, 12, "room d")
+
+		if it.Size() != 4 {
+			t.Errorf("Expected size 4 counting repeated intervals, got %d", it.Size())
+		}
+
+		expected := []string{"[8,12)=room d", "[9,10)=room a", "[9,10)=room b", "[9,10)=room c"}
+		if result := collectIntervals(it.Overlaps(9)); !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+		if result := collectIntervals(it.ForwardIterator()); !slices.Equal(result, expected) {
+			t.Errorf("Expected %v, got %v", expected, result)
+		}
+
+		// values come back in insertion order and the copy does not alias the tree
+		values := it.Search(9, 10)
+		if !slices.Equal(values, []string{"room a", "room b", "room c"}) {
+			t.Errorf("Expected values in insertion order, got %v", values)
+		}
+		values[0] = "changed"
+		if it.Search(9, 10)[0] != "room a" {
+			t.Error("Modifying the result of Search should not change the tree")
+		}
+
+		// Delete removes the oldest value only
+		if !it.Delete(9, 10) || !slices.Equal(it.Search(9, 10), []string{"room b", "room c"}) {
+			t.Errorf("Expected oldest value removed, got %v", it.Search(9, 10))
+		}
+		if removed := it.DeleteAll(9, 10); removed != 2 || it.Size() != 1 {
+			t.Errorf("Expected DeleteAll to remove 2 values leaving 1, removed %d leaving %d", removed, it.Size())
+		}
+		if it.DeleteAll(9, 10) != 0 {
+			t.Error("DeleteAll of missing interval should remove nothing")
+		}
+		verifyRedBlackProperties(t, it.tree)
+	})
+
+	t.Run("test against brute force", func(t *testing.T) {
+		random := rand.New(rand.NewSource(7))
+		it := NewIntervalTree[int, string]()
+		reference := map[Interval[int]]int{}
+
+		for i := 0; i < 1500; i++ {
+			// small range of end points, so plenty of intervals are inserted more than once
+			start := random.Intn(100)
+			end := start + 1 + random.Intn(10)
+			if random.Intn(4) == 0 && len(reference) > 0 {
+				for interval := range reference {
+					it.Delete(interval.Start, interval.End)
+					reference[interval]--
+					if reference[interval] == 0 {
+						delete(reference, interval)
+					}
+					break
+				}
+			} else {
+				it.Insert(start, end, fmt.Sprint(i))
+				reference[Interval[int]{start, end}]++
+			}
+
+			if i%50 != 0 {
+				continue
+			}
+			verifyRedBlackProperties(t, it.tree)
+
+			lo := random.Intn(120)
+			hi := lo + random.Intn(15)
+
+			expectedPoint, expectedRange := 0, 0
+			expectedSize := 0
+			for interval, count := range reference {
+				expectedSize += count
+				if interval.Start <= lo && lo < interval.End {
+					expectedPoint += count
+				}
+				if lo < hi && interval.Start < hi && interval.End > lo {
+					expectedRange += count
+				}
+			}
+
+			if it.Size() != expectedSize {
+				t.Errorf("Expected size %d, got %d", expectedSize, it.Size())
+			}
+
+			if result := collectIntervals(it.Overlaps(lo)); len(result) != expectedPoint {
+				t.Errorf("Overlaps(%d): expected %d intervals, got %d", lo, expectedPoint, len(result))
+			}
+
+			if result := collectIntervals(it.OverlapsRange(lo, hi)); len(result) != expectedRange {
+				t.Errorf("OverlapsRange(%d, %d): expected %d intervals, got %d", lo, hi, expectedRange, len(result))
+			}
+		}
+	})
+
+	t.Run("test early termination", func(t *testing.T) {
+		it := NewIntervalTree[int, string]()
+		for i := 0; i < 100; i++ {
+			it.Insert(i, i+50, fmt.Sprint(i))
+		}
+
+		count := 0
+		for range it.Overlaps(60) {
+			count++
+			if count == 3 {
+				break
+			}
+		}
+
+		if count != 3 {
+			t.Errorf("Expected to stop after 3 intervals, got %d", count)
+		}
+	})
+}
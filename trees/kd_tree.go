@@ -0,0 +1,307 @@
+package trees
+
+/*
+	1. k-d tree is a binary search tree over points in k dimensional space.
+	2. Every level splits the space along one axis, cycling through the axes with the depth,
+	   points with a smaller coordinate on that axis go left, the rest go right.
+	3. Whole subtrees are skipped when the splitting plane is farther away than anything
+	   the query is looking for, which makes nearest neighbour and range queries fast on average.
+	4. Build creates a balanced tree, Insert does not rebalance, so build again after many inserts.
+*/
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/charmingbiswas/golang-stl/heap"
+)
+
+var (
+	ErrDimensionMismatch = errors.New("point has wrong number of dimensions")
+	ErrNegativeRadius    = errors.New("radius is negative")
+)
+
+// KDPoint is a point of a KDTree together with it's value.
+type KDPoint[V any] struct {
+	Coords []float64
+	Value  V
+}
+
+type kdNode[V any] struct {
+	point []float64
+	value V
+	// axis this node splits the space on
+	axis  int
+	left  *kdNode[V]
+	right *kdNode[V]
+}
+
+type KDTree[V any] struct {
+	root     *kdNode[V]
+	dims     int
+	treeSize int
+}
+
+// Candidate of a nearest neighbour search, kept in a max heap on distance.
+type kdCandidate[V any] struct {
+	node     *kdNode[V]
+	distance float64
+}
+
+// Returns a pointer to an empty KDTree over points with 'dims' coordinates.
+// Values less than 1 are treated as 1.
+func NewKDTree[V any](dims int) *KDTree[V] {
+	return &KDTree[V]{
+		root:     nil,
+		dims:     max(dims, 1),
+		treeSize: 0,
+	}
+}
+
+// Replaces the contents of the tree with the given points and their values, building a balanced tree.
+// Returns ErrLengthMismatch if points and values have different lengths,
+// ErrDimensionMismatch if any point has the wrong number of coordinates,
+// the tree is left unchanged in both cases.
+// Runs in O(n log^2 n) time.
+func (t *KDTree[V]) Build(points [][]float64, values []V) error {
+	if len(points) != len(values) {
+		return fmt.Errorf("%w: %d points and %d values", ErrLengthMismatch, len(points), len(values))
+	}
+	for _, point := range points {
+		if err := t.checkDimensions(point); err != nil {
+			return err
+		}
+	}
+
+	nodes := make([]*kdNode[V], len(points))
+	for i := range points {
+		nodes[i] = &kdNode[V]{point: slices.Clone(points[i]), value: values[i]}
+	}
+
+	t.root = t.build(nodes, 0)
+	t.treeSize = len(nodes)
+	return nil
+}
+
+// Inserts a point with it's value into the tree.
+// Points are not unique, inserting the same point twice stores it twice.
+// Returns ErrDimensionMismatch if the point has the wrong number of coordinates.
+func (t *KDTree[V]) Insert(point []float64, value V) error {
+	if err := t.checkDimensions(point); err != nil {
+		return err
+	}
+
+	link, depth := &t.root, 0
+	for *link != nil {
+		node := *link
+		if point[node.axis] < node.point[node.axis] {
+			link = &node.left
+		} else {
+			link = &node.right
+		}
+		depth++
+	}
+
+	// the axis cycles with the depth
+	*link = &kdNode[V]{point: slices.Clone(point), value: value, axis: depth % t.dims}
+	t.treeSize++
+	return nil
+}
+
+// Returns the k points closest to the query point by euclidean distance, closest first.
+// Returns fewer points if the tree holds less than k points.
+// Returns ErrDimensionMismatch if the query point has the wrong number of coordinates.
+func (t *KDTree[V]) Nearest(query []float64, k int) ([]KDPoint[V], error) {
+	if err := t.checkDimensions(query); err != nil {
+		return nil, err
+	}
+	if k <= 0 {
+		return nil, nil
+	}
+
+	// farthest candidate on top, so it is the one replaced by a closer point
+	best := heap.NewHeapWithFunc(func(a, b kdCandidate[V]) bool { return a.distance > b.distance })
+	t.nearest(t.root, query, k, best)
+
+	result := make([]KDPoint[V], best.Size())
+	for i := len(result) - 1; i >= 0; i-- {
+		result[i] = best.Top().node.toPoint()
+		best.Pop()
+	}
+	return result, nil
+}
+
+// Returns all points within 'radius' of the center by euclidean distance, boundary included.
+// Points are returned in no particular order.
+// Returns ErrDimensionMismatch if the center has the wrong number of coordinates,
+// ErrNegativeRadius if the radius is less than 0.
+func (t *KDTree[V]) WithinRadius(center []float64, radius float64) ([]KDPoint[V], error) {
+	if err := t.checkDimensions(center); err != nil {
+		return nil, err
+	}
+	if radius < 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNegativeRadius, radius)
+	}
+
+	var result []KDPoint[V]
+	t.withinRadius(t.root, center, radius, &result)
+	return result, nil
+}
+
+// Returns all points inside the axis aligned box between the lower and upper corner, boundary included.
+// Points are returned in no particular order.
+// Returns ErrDimensionMismatch if either corner has the wrong number of coordinates.
+func (t *KDTree[V]) InBox(lower, upper []float64) ([]KDPoint[V], error) {
+	if err := t.checkDimensions(lower); err != nil {
+		return nil, err
+	}
+	if err := t.checkDimensions(upper); err != nil {
+		return nil, err
+	}
+
+	var result []KDPoint[V]
+	t.inBox(t.root, lower, upper, &result)
+	return result, nil
+}
+
+// Returns the number of coordinates of every point in the tree.
+func (t *KDTree[V]) Dimensions() int {
+	return t.dims
+}
+
+// Returns true if tree is empty, otherwise false.
+func (t *KDTree[V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Returns the current number of points in the tree.
+func (t *KDTree[V]) Size() int {
+	return t.treeSize
+}
+
+// Clears and resets the tree to an empty tree.
+func (t *KDTree[V]) Clear() {
+	t.root = nil
+	t.treeSize = 0
+}
+
+// Builds a balanced subtree out of the nodes, splitting at the median of the axis for 'depth'.
+// Returns the root of the subtree.
+func (t *KDTree[V]) build(nodes []*kdNode[V], depth int) *kdNode[V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	axis := depth % t.dims
+	slices.SortFunc(nodes, func(a, b *kdNode[V]) int {
+		return cmp.Compare(a.point[axis], b.point[axis])
+	})
+
+	// move the median left past equal coordinates, so the left subtree only holds smaller ones
+	mid := len(nodes) / 2
+	for mid > 0 && nodes[mid-1].point[axis] == nodes[mid].point[axis] {
+		mid--
+	}
+
+	root := nodes[mid]
+	root.axis = axis
+	root.left = t.build(nodes[:mid], depth+1)
+	root.right = t.build(nodes[mid+1:], depth+1)
+	return root
+}
+
+// Visits the subtree rooted at 'node', keeping the k closest points found so far in 'best'.
+func (t *KDTree[V]) nearest(node *kdNode[V], query []float64, k int, best *heap.Heap[kdCandidate[V]]) {
+	if node == nil {
+		return
+	}
+
+	distance := squaredDistance(query, node.point)
+	if best.Size() < k {
+		best.Push(kdCandidate[V]{node: node, distance: distance})
+	} else if distance < best.Top().distance {
+		best.Pop()
+		best.Push(kdCandidate[V]{node: node, distance: distance})
+	}
+
+	// search the side of the query first, it is the most likely to hold close points
+	diff := query[node.axis] - node.point[node.axis]
+	near, far := node.left, node.right
+	if diff >= 0 {
+		near, far = node.right, node.left
+	}
+
+	t.nearest(near, query, k, best)
+	// the other side can only help if the splitting plane is closer than the worst candidate
+	if best.Size() < k || diff*diff < best.Top().distance {
+		t.nearest(far, query, k, best)
+	}
+}
+
+// Collects points of the subtree rooted at 'node' within 'radius' of the center.
+func (t *KDTree[V]) withinRadius(node *kdNode[V], center []float64, radius float64, result *[]KDPoint[V]) {
+	if node == nil {
+		return
+	}
+
+	if squaredDistance(center, node.point) <= radius*radius {
+		*result = append(*result, node.toPoint())
+	}
+
+	split := node.point[node.axis]
+	if center[node.axis]-radius < split {
+		t.withinRadius(node.left, center, radius, result)
+	}
+	if center[node.axis]+radius >= split {
+		t.withinRadius(node.right, center, radius, result)
+	}
+}
+
+// Collects points of the subtree rooted at 'node' inside the box.
+func (t *KDTree[V]) inBox(node *kdNode[V], lower, upper []float64, result *[]KDPoint[V]) {
+	if node == nil {
+		return
+	}
+
+	inside := true
+	for i, coord := range node.point {
+		if coord < lower[i] || coord > upper[i] {
+			inside = false
+			break
+		}
+	}
+	if inside {
+		*result = append(*result, node.toPoint())
+	}
+
+	split := node.point[node.axis]
+	if lower[node.axis] < split {
+		t.inBox(node.left, lower, upper, result)
+	}
+	if upper[node.axis] >= split {
+		t.inBox(node.right, lower, upper, result)
+	}
+}
+
+func (t *KDTree[V]) checkDimensions(point []float64) error {
+	if len(point) != t.dims {
+		return fmt.Errorf("%w: got %d, want %d", ErrDimensionMismatch, len(point), t.dims)
+	}
+	return nil
+}
+
+// Returns a copy of the node's point, so callers cannot move points inside the tree.
+func (n *kdNode[V]) toPoint() KDPoint[V] {
+	return KDPoint[V]{Coords: slices.Clone(n.point), Value: n.value}
+}
+
+func squaredDistance(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		diff := a[i] - b[i]
+		sum += diff * diff
+	}
+	return sum
+}
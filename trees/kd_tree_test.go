@@ -0,0 +1,197 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func randomPoints(r *rand.Rand, count, dims int) [][]float64 {
+	points := make([][]float64, count)
+	for i := range points {
+		points[i] = make([]float64, dims)
+		for j := range points[i] {
+			// integer coordinates, so plenty of points share a coordinate with the splitting plane
+			points[i][j] = float64(r.Intn(50))
+		}
+	}
+	return points
+}
+
+// Returns the sorted values of the points, query results come in no particular order
+func pointValues(points []KDPoint[int]) []int {
+	values := make([]int, len(points))
+	for i, point := range points {
+		values[i] = point.Value
+	}
+	slices.Sort(values)
+	return values
+}
+
+// Checks all queries against a linear scan over the same points
+func TestKDTreeBruteForce(t *testing.T) {
+	r := rand.New(rand.NewSource(6))
+
+	for _, dims := range []int{1, 2, 3, 5} {
+		points := randomPoints(r, 500, dims)
+		values := make([]int, len(points))
+		for i := range values {
+			values[i] = i
+		}
+
+		built := NewKDTree[int](dims)
+		if err := built.Build(points, values); err != nil {
+			t.Fatal(err)
+		}
+		inserted := NewKDTree[int](dims)
+		for i, point := range points {
+			if err := inserted.Insert(point, i); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		for _, tree := range []*KDTree[int]{built, inserted} {
+			if tree.Size() != len(points) {
+				t.Fatalf("Expected size %d, got %d", len(points), tree.Size())
+			}
+
+			for range 50 {
+				query := randomPoints(r, 1, dims)[0]
+
+				// k nearest, compared by distance since equally distant points can come in any order
+				k := 1 + r.Intn(10)
+				distances := make([]float64, len(points))
+				for i, point := range points {
+					distances[i] = squaredDistance(query, point)
+				}
+				slices.Sort(distances)
+
+				nearest, err := tree.Nearest(query, k)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(nearest) != k {
+					t.Fatalf("Nearest returned %d points, want %d", len(nearest), k)
+				}
+				for i, point := range nearest {
+					if got := squaredDistance(query, point.Coords); got != distances[i] {
+						t.Fatalf("%dD: neighbour %d at squared distance %v, want %v", dims, i, got, distances[i])
+					}
+					if !slices.Equal(point.Coords, points[point.Value]) {
+						t.Fatalf("Neighbour %d has coordinates %v, want %v", i, point.Coords, points[point.Value])
+					}
+				}
+
+				radius := float64(r.Intn(20))
+				var wantRadius []int
+				for i, point := range points {
+					if squaredDistance(query, point) <= radius*radius {
+						wantRadius = append(wantRadius, i)
+					}
+				}
+				within, _ := tree.WithinRadius(query, radius)
+				if got := pointValues(within); !slices.Equal(got, wantRadius) {
+					t.Fatalf("%dD: WithinRadius(%v, %v) = %v, want %v", dims, query, radius, got, wantRadius)
+				}
+
+				corner := randomPoints(r, 1, dims)[0]
+				lower, upper := make([]float64, dims), make([]float64, dims)
+				for i := range lower {
+					lower[i], upper[i] = min(query[i], corner[i]), max(query[i], corner[i])
+				}
+				var wantBox []int
+				for i, point := range points {
+					inside := true
+					for j := range point {
+						inside = inside && lower[j] <= point[j] && point[j] <= upper[j]
+					}
+					if inside {
+						wantBox = append(wantBox, i)
+					}
+				}
+				box, _ := tree.InBox(lower, upper)
+				if got := pointValues(box); !slices.Equal(got, wantBox) {
+					t.Fatalf("%dD: InBox(%v, %v) = %v, want %v", dims, lower, upper, got, wantBox)
+				}
+			}
+		}
+	}
+}
+
+func TestKDTreeErrors(t *testing.T) {
+	tree := NewKDTree[string](2)
+	if tree.Dimensions() != 2 || !tree.IsEmpty() {
+		t.Fatal("Expected empty 2D tree")
+	}
+
+	if err := tree.Insert([]float64{1}, "a"); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if err := tree.Build([][]float64{{1, 2}}, nil); !errors.Is(err, ErrLengthMismatch) {
+		t.Errorf("Expected ErrLengthMismatch, got %v", err)
+	}
+	if err := tree.Build([][]float64{{1, 2}, {1, 2, 3}}, []string{"a", "b"}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if !tree.IsEmpty() {
+		t.Error("Failed Build should leave the tree unchanged")
+	}
+
+	if _, err := tree.Nearest([]float64{1, 2, 3}, 1); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := tree.WithinRadius(nil, 1); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := tree.InBox([]float64{0, 0}, []float64{1}); !errors.Is(err, ErrDimensionMismatch) {
+		t.Errorf("Expected ErrDimensionMismatch, got %v", err)
+	}
+	if _, err := tree.WithinRadius([]float64{0, 0}, -1); !errors.Is(err, ErrNegativeRadius) {
+		t.Errorf("Expected ErrNegativeRadius, got %v", err)
+	}
+
+	if nearest, err := tree.Nearest([]float64{0, 0}, 3); err != nil || len(nearest) != 0 {
+		t.Errorf("Nearest on empty tree = %v, %v", nearest, err)
+	}
+
+	tree.Insert([]float64{1, 1}, "a")
+	tree.Insert([]float64{2, 2}, "b")
+	if nearest, _ := tree.Nearest([]float64{0, 0}, 5); len(nearest) != 2 || nearest[0].Value != "a" {
+		t.Errorf("Expected both points nearest first, got %v", nearest)
+	}
+	if nearest, _ := tree.Nearest([]float64{0, 0}, 0); nearest != nil {
+		t.Errorf("Nearest with k = 0 should return nothing, got %v", nearest)
+	}
+
+	// returned coordinates are copies
+	nearest, _ := tree.Nearest([]float64{0, 0}, 1)
+	nearest[0].Coords[0] = 100
+	if again, _ := tree.Nearest([]float64{0, 0}, 1); again[0].Coords[0] != 1 {
+		t.Error("Modifying a result should not move the point in the tree")
+	}
+
+	// a zero radius only finds points exactly at the center
+	if within, err := tree.WithinRadius([]float64{2, 2}, 0); err != nil || len(within) != 1 || within[0].Value != "b" {
+		t.Errorf("WithinRadius with radius 0 = %v, %v, want only b", within, err)
+	}
+
+	tree.Clear()
+	if !tree.IsEmpty() || tree.Size() != 0 {
+		t.Error("Clear should empty the tree")
+	}
+}
+
+func BenchmarkKDTreeNearest(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	points := make([][]float64, 100_000)
+	for i := range points {
+		points[i] = []float64{r.Float64(), r.Float64()}
+	}
+	tree := NewKDTree[int](2)
+	tree.Build(points, make([]int, len(points)))
+
+	for b.Loop() {
+		tree.Nearest([]float64{r.Float64(), r.Float64()}, 10)
+	}
+}
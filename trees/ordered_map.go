@@ -0,0 +1,29 @@
+package trees
+
+import "iter"
+
+// OrderedMap is the method set shared by the ordered map implementations of this package,
+// so they can be swapped for each other and benchmarked against each other.
+// Implemented by RedBlackTree, AVLTree, BTree, Treap and SplayTree.
+type OrderedMap[T any, V any] interface {
+	// Inserts a key-value pair, updates the value if the key already exists.
+	Insert(key T, value V)
+	// Deletes a key, returns false if it does not exist.
+	Delete(key T) bool
+	// Returns the value stored for the key, boolean is false if it does not exist.
+	Get(key T) (V, bool)
+	// Returns the number of keys.
+	Size() int
+	// Returns true if there are no keys.
+	IsEmpty() bool
+	// Removes all keys.
+	Clear()
+	// Iterates over all key-value pairs in ascending key order.
+	ForwardIterator() iter.Seq2[T, V]
+	// Iterates over all key-value pairs in descending key order.
+	BackwardIterator() iter.Seq2[T, V]
+	// Iterates over keys between lo and hi in ascending order.
+	Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V]
+	// Iterates over keys between hi and lo in descending order.
+	RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V]
+}
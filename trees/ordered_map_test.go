@@ -0,0 +1,205 @@
+package trees
+
+import (
+	"math/rand"
+	"runtime"
+	"slices"
+	"testing"
+)
+
+var (
+	_ OrderedMap[int, int] = (*RedBlackTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*AVLTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*BTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*Treap[int, int])(nil)
+	_ OrderedMap[int, int] = (*SplayTree[int, int])(nil)
+)
+
+// All ordered map implementations, the conformance test and benchmarks run against each of them.
+var orderedMapImplementations = []struct {
+	name string
+	new  func() OrderedMap[int, int]
+}{
+	{"RedBlackTree", func() OrderedMap[int, int] { return NewRedBlackTree[int, int]() }},
+	{"AVLTree", func() OrderedMap[int, int] { return NewAVLTree[int, int]() }},
+	{"BTree", func() OrderedMap[int, int] { return NewBTree[int, int](DefaultBTreeDegree) }},
+	{"Treap", func() OrderedMap[int, int] { return NewTreap[int, int](1) }},
+	{"SplayTree", func() OrderedMap[int, int] { return NewSplayTree[int, int]() }},
+}
+
+func TestOrderedMapConformance(t *testing.T) {
+	for _, impl := range orderedMapImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			m := impl.new()
+			reference := map[int]int{}
+			r := rand.New(rand.NewSource(42))
+
+			// random mix of inserts, updates and deletes checked against a builtin map
+			for range 5000 {
+				key := r.Intn(500)
+				if r.Intn(3) == 0 {
+					_, exists := reference[key]
+					if m.Delete(key) != exists {
+						t.Fatalf("Delete(%d) disagrees with reference", key)
+					}
+					delete(reference, key)
+				} else {
+					m.Insert(key, key*2)
+					reference[key] = key * 2
+				}
+			}
+
+			if m.Size() != len(reference) {
+				t.Fatalf("Size = %d, want %d", m.Size(), len(reference))
+			}
+			for key := range 500 {
+				want, wantOk := reference[key]
+				if got, ok := m.Get(key); got != want || ok != wantOk {
+					t.Fatalf("Get(%d) = %d, %v, want %d, %v", key, got, ok, want, wantOk)
+				}
+			}
+
+			var sorted []int
+			for key := range reference {
+				sorted = append(sorted, key)
+			}
+			slices.Sort(sorted)
+
+			var forward, backward, ranged, rangedDesc []int
+			for key := range m.ForwardIterator() {
+				forward = append(forward, key)
+			}
+			for key := range m.BackwardIterator() {
+				backward = append(backward, key)
+			}
+			for key := range m.Range(100, 200, ExcludeHigh) {
+				ranged = append(ranged, key)
+			}
+			for key := range m.RangeDesc(200, 100, ExcludeLow) {
+				rangedDesc = append(rangedDesc, key)
+			}
+
+			if !slices.Equal(forward, sorted) {
+				t.Errorf("ForwardIterator = %v, want %v", forward, sorted)
+			}
+			reversed := slices.Clone(sorted)
+			slices.Reverse(reversed)
+			if !slices.Equal(backward, reversed) {
+				t.Errorf("BackwardIterator = %v, want %v", backward, reversed)
+			}
+
+			var wantRange, wantRangeDesc []int
+			for _, key := range sorted {
+				if key >= 100 && key < 200 {
+					wantRange = append(wantRange, key)
+				}
+				if key > 100 && key <= 200 {
+					wantRangeDesc = append([]int{key}, wantRangeDesc...)
+				}
+			}
+			if !slices.Equal(ranged, wantRange) {
+				t.Errorf("Range = %v, want %v", ranged, wantRange)
+			}
+			if !slices.Equal(rangedDesc, wantRangeDesc) {
+				t.Errorf("RangeDesc = %v, want %v", rangedDesc, wantRangeDesc)
+			}
+
+			m.Clear()
+			if !m.IsEmpty() || m.Size() != 0 {
+				t.Errorf("Clear did not empty the map, size %d", m.Size())
+			}
+		})
+	}
+}
+
+const orderedMapBenchmarkSize = 100_000
+
+func BenchmarkOrderedMapInsertRandom(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, impl := range orderedMapImplementations {
+		b.Run(impl.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				m := impl.new()
+				for _, key := range keys {
+					m.Insert(key, key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedMapGet(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, impl := range orderedMapImplementations {
+		m := impl.new()
+		for _, key := range keys {
+			m.Insert(key, key)
+		}
+		b.Run(impl.name, func(b *testing.B) {
+			for b.Loop() {
+				for _, key := range keys {
+					m.Get(key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedMapDelete(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, impl := range orderedMapImplementations {
+		b.Run(impl.name, func(b *testing.B) {
+			for b.Loop() {
+				b.StopTimer()
+				m := impl.new()
+				for _, key := range keys {
+					m.Insert(key, key)
+				}
+				b.StartTimer()
+				for _, key := range keys {
+					m.Delete(key)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrderedMapIterate(b *testing.B) {
+	for _, impl := range orderedMapImplementations {
+		m := impl.new()
+		for i := range orderedMapBenchmarkSize {
+			m.Insert(i, i)
+		}
+		b.Run(impl.name, func(b *testing.B) {
+			for b.Loop() {
+				for range m.ForwardIterator() {
+				}
+			}
+		})
+	}
+}
+
+// Reports the live heap held by a filled map, which is what matters at tens of millions of keys.
+func BenchmarkOrderedMapMemory(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	for _, impl := range orderedMapImplementations {
+		b.Run(impl.name, func(b *testing.B) {
+			var before, after runtime.MemStats
+			var m OrderedMap[int, int]
+			for b.Loop() {
+				// drop the map of the previous iteration before measuring the baseline
+				m = nil
+				runtime.GC()
+				runtime.ReadMemStats(&before)
+				m = impl.new()
+				for _, key := range keys {
+					m.Insert(key, key)
+				}
+				runtime.GC()
+				runtime.ReadMemStats(&after)
+			}
+			b.ReportMetric(float64(after.HeapAlloc-before.HeapAlloc)/float64(m.Size()), "bytes/key")
+		})
+	}
+}
@@ -0,0 +1,376 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/charmingbiswas/golang-stl/stack"
+)
+
+// PersistentMap is an immutable ordered map.
+// Insert and Delete never modify the map they are called on, they return a new version instead.
+// New versions share all untouched nodes with the old one (path copying),
+// so every update allocates only O(log n) nodes and old versions stay valid forever.
+// Safe for concurrent reads, a version can be handed to other goroutines as a snapshot.
+//
+// Internally the map is an AVL tree, which keeps persistent deletion simple.
+type PersistentMap[T any, V any] struct {
+	root    *persistentNode[T, V]
+	compare func(a, b T) int
+}
+
+type persistentNode[T any, V any] struct {
+	key    T
+	value  V
+	left   *persistentNode[T, V]
+	right  *persistentNode[T, V]
+	height int
+	size   int
+}
+
+// Returns a pointer to an empty PersistentMap.
+// Works with default built in types.
+func NewPersistentMap[T cmp.Ordered, V any]() *PersistentMap[T, V] {
+	return NewPersistentMapWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an empty PersistentMap.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewPersistentMapWithFunc[T any, V any](comparator func(a, b T) int) *PersistentMap[T, V] {
+	return &PersistentMap[T, V]{
+		root:    nil,
+		compare: comparator,
+	}
+}
+
+// Returns a new version of the map with the key-value pair inserted.
+// If the key already exists, the new version has the updated value.
+// The receiver is not modified.
+func (m *PersistentMap[T, V]) Insert(key T, value V) *PersistentMap[T, V] {
+	return &PersistentMap[T, V]{
+		root:    m.insert(m.root, key, value),
+		compare: m.compare,
+	}
+}
+
+// Returns a new version of the map without the key.
+// Boolean is false if the key does not exist, the receiver itself is returned in that case.
+// The receiver is not modified.
+func (m *PersistentMap[T, V]) Delete(key T) (*PersistentMap[T, V], bool) {
+	root, ok := m.delete(m.root, key)
+	if !ok {
+		return m, false
+	}
+
+	return &PersistentMap[T, V]{
+		root:    root,
+		compare: m.compare,
+	}, true
+}
+
+// Searches for a key in the map.
+// Returns the value and boolean value.
+// Boolean is true if key is found, otherwise false.
+func (m *PersistentMap[T, V]) Search(key T) (V, bool) {
+	currentNode := m.root
+
+	for currentNode != nil {
+		result := m.compare(key, currentNode.key)
+		if result == 0 {
+			return currentNode.value, true
+		} else if result < 0 {
+			currentNode = currentNode.left
+		} else {
+			currentNode = currentNode.right
+		}
+	}
+
+	return *new(V), false
+}
+
+// Returns the current number of keys in the map.
+func (m *PersistentMap[T, V]) Size() int {
+	return m.root.getSize()
+}
+
+// Returns true if map is empty, otherwise false.
+func (m *PersistentMap[T, V]) IsEmpty() bool {
+	return m.root == nil
+}
+
+// Returns a 'push' iterator to the map.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (m *PersistentMap[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*persistentNode[T, V]]()
+		m.pushLeft(path, m.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			m.pushLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator to the map.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (m *PersistentMap[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*persistentNode[T, V]]()
+		m.pushRight(path, m.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			m.pushRight(path, node.left)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in ascending order.
+// Both bounds are inclusive unless ExcludeLow or ExcludeHigh options are passed, same as RedBlackTree.Range.
+func (m *PersistentMap[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		// descend to the first key in range, remembering every node we still need to visit
+		path := stack.NewStack[*persistentNode[T, V]]()
+		for node := m.root; node != nil; {
+			result := m.compare(node.key, lo)
+			if result > 0 || (result == 0 && !excludeLow) {
+				path.Push(node)
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := m.compare(node.key, hi)
+			if result > 0 || (result == 0 && excludeHigh) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			m.pushLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in descending order, starting from hi.
+// Both bounds are inclusive unless ExcludeLow or ExcludeHigh options are passed, same as RedBlackTree.RangeDesc.
+func (m *PersistentMap[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*persistentNode[T, V]]()
+		for node := m.root; node != nil; {
+			result := m.compare(node.key, hi)
+			if result < 0 || (result == 0 && !excludeHigh) {
+				path.Push(node)
+				node = node.right
+			} else {
+				node = node.left
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := m.compare(node.key, lo)
+			if result < 0 || (result == 0 && excludeLow) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			m.pushRight(path, node.left)
+		}
+	}
+}
+
+// Pushes 'node' and all of it's left descendants onto the stack.
+func (m *PersistentMap[T, V]) pushLeft(path *stack.Stack[*persistentNode[T, V]], node *persistentNode[T, V]) {
+	for node != nil {
+		path.Push(node)
+		node = node.left
+	}
+}
+
+// Pushes 'node' and all of it's right descendants onto the stack.
+func (m *PersistentMap[T, V]) pushRight(path *stack.Stack[*persistentNode[T, V]], node *persistentNode[T, V]) {
+	for node != nil {
+		path.Push(node)
+		node = node.right
+	}
+}
+
+// Returns the root of a new subtree with the key inserted.
+// Only nodes on the path from 'node' to the inserted key are copied.
+func (m *PersistentMap[T, V]) insert(node *persistentNode[T, V], key T, value V) *persistentNode[T, V] {
+	if node == nil {
+		return &persistentNode[T, V]{key: key, value: value, height: 1, size: 1}
+	}
+
+	result := m.compare(key, node.key)
+	copied := node.clone()
+
+	if result < 0 {
+		copied.left = m.insert(node.left, key, value)
+	} else if result > 0 {
+		copied.right = m.insert(node.right, key, value)
+	} else {
+		// exact key found, only the value changes, no rebalancing needed
+		copied.value = value
+		return copied
+	}
+
+	return copied.rebalance()
+}
+
+// Returns the root of a new subtree with the key removed.
+// Boolean is false if the key does not exist, nothing is copied in that case.
+func (m *PersistentMap[T, V]) delete(node *persistentNode[T, V], key T) (*persistentNode[T, V], bool) {
+	if node == nil {
+		return nil, false
+	}
+
+	result := m.compare(key, node.key)
+	if result < 0 {
+		left, ok := m.delete(node.left, key)
+		if !ok {
+			return node, false
+		}
+		copied := node.clone()
+		copied.left = left
+		return copied.rebalance(), true
+	} else if result > 0 {
+		right, ok := m.delete(node.right, key)
+		if !ok {
+			return node, false
+		}
+		copied := node.clone()
+		copied.right = right
+		return copied.rebalance(), true
+	}
+
+	// found the node to be deleted
+	if node.left == nil {
+		return node.right, true
+	} else if node.right == nil {
+		return node.left, true
+	}
+
+	// both children exist, replace node with it's inorder successor
+	right, successor := node.right.deleteMin()
+	replacement := successor.clone()
+	replacement.left = node.left
+	replacement.right = right
+	return replacement.rebalance(), true
+}
+
+// Returns the root of a new subtree without it's smallest node, and the removed node.
+func (n *persistentNode[T, V]) deleteMin() (*persistentNode[T, V], *persistentNode[T, V]) {
+	if n.left == nil {
+		return n.right, n
+	}
+
+	left, smallest := n.left.deleteMin()
+	copied := n.clone()
+	copied.left = left
+	return copied.rebalance(), smallest
+}
+
+// Returns a shallow copy of the node which can be modified freely.
+func (n *persistentNode[T, V]) clone() *persistentNode[T, V] {
+	copied := *n
+	return &copied
+}
+
+func (n *persistentNode[T, V]) getHeight() int {
+	if n == nil {
+		return 0
+	}
+	return n.height
+}
+
+func (n *persistentNode[T, V]) getSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
+
+// Recomputes height and size from the children.
+func (n *persistentNode[T, V]) update() {
+	n.height = max(n.left.getHeight(), n.right.getHeight()) + 1
+	n.size = n.left.getSize() + n.right.getSize() + 1
+}
+
+// Restores the AVL property of a freshly copied node and returns the new subtree root.
+// Children taking part in rotations are copied as well, since they may be shared with older versions.
+func (n *persistentNode[T, V]) rebalance() *persistentNode[T, V] {
+	n.update()
+	balance := n.left.getHeight() - n.right.getHeight()
+
+	if balance > 1 {
+		if n.left.left.getHeight() < n.left.right.getHeight() {
+			// left-right case, turn it into left-left first
+			n.left = n.left.clone().rotateLeft()
+		}
+		return n.rotateRight()
+	}
+
+	if balance < -1 {
+		if n.right.right.getHeight() < n.right.left.getHeight() {
+			// right-left case, turn it into right-right first
+			n.right = n.right.clone().rotateRight()
+		}
+		return n.rotateLeft()
+	}
+
+	return n
+}
+
+// Rotates a freshly copied node to the left and returns the new subtree root.
+func (n *persistentNode[T, V]) rotateLeft() *persistentNode[T, V] {
+	newRoot := n.right.clone()
+	n.right = newRoot.left
+	newRoot.left = n
+	n.update()
+	newRoot.update()
+	return newRoot
+}
+
+// Rotates a freshly copied node to the right and returns the new subtree root.
+func (n *persistentNode[T, V]) rotateRight() *persistentNode[T, V] {
+	newRoot := n.left.clone()
+	n.left = newRoot.right
+	newRoot.right = n
+	n.update()
+	newRoot.update()
+	return newRoot
+}
@@ -0,0 +1,161 @@
+package trees
+
+import (
+	"fmt"
+	"iter"
+	"slices"
+	"testing"
+)
+
+// Verifies AVL balance, BST ordering and cached sizes, returns the height of the subtree.
+func verifyPersistentNode[T any, V any](t *testing.T, m *PersistentMap[T, V], node *persistentNode[T, V]) int {
+	if node == nil {
+		return 0
+	}
+
+	if node.left != nil && m.compare(node.left.key, node.key) >= 0 {
+		t.Errorf("Ordering violation: left child %v is not smaller than %v", node.left.key, node.key)
+	}
+
+	if node.right != nil && m.compare(node.right.key, node.key) <= 0 {
+		t.Errorf("Ordering violation: right child %v is not greater than %v", node.right.key, node.key)
+	}
+
+	leftHeight := verifyPersistentNode(t, m, node.left)
+	rightHeight := verifyPersistentNode(t, m, node.right)
+
+	if leftHeight-rightHeight > 1 || rightHeight-leftHeight > 1 {
+		t.Errorf("Balance violation at node %v (left: %d, right: %d)", node.key, leftHeight, rightHeight)
+	}
+
+	if node.height != max(leftHeight, rightHeight)+1 {
+		t.Errorf("Height mismatch at node %v", node.key)
+	}
+
+	if node.size != node.left.getSize()+node.right.getSize()+1 {
+		t.Errorf("Size mismatch at node %v", node.key)
+	}
+
+	return node.height
+}
+
+func collectPairs(seq iter.Seq2[int, string]) []string {
+	pairs := make([]string, 0)
+	for key, value := range seq {
+		pairs = append(pairs, fmt.Sprintf("%d=%s", key, value))
+	}
+	return pairs
+}
+
+func TestPersistentMap(t *testing.T) {
+	t.Run("test insert search delete", func(t *testing.T) {
+		m := NewPersistentMap[int, string]()
+		if !m.IsEmpty() || m.Size() != 0 {
+			t.Error("New map should be empty")
+		}
+
+		for i := 0; i < 200; i++ {
+			key := (i * 37) % 200
+			m = m.Insert(key, fmt.Sprint(key))
+			verifyPersistentNode(t, m, m.root)
+		}
+
+		if m.Size() != 200 {
+			t.Errorf("Expected size 200, got %d", m.Size())
+		}
+
+		for i := 0; i < 200; i++ {
+			if value, found := m.Search(i); !found || value != fmt.Sprint(i) {
+				t.Errorf("Failed to find key %d", i)
+			}
+		}
+
+		for i := 0; i < 200; i += 2 {
+			var deleted bool
+			m, deleted = m.Delete(i)
+			if !deleted {
+				t.Errorf("Failed to delete key %d", i)
+			}
+			verifyPersistentNode(t, m, m.root)
+		}
+
+		if m.Size() != 100 {
+			t.Errorf("Expected size 100, got %d", m.Size())
+		}
+
+		if same, deleted := m.Delete(0); deleted || same != m {
+			t.Error("Deleting a missing key should return the same version")
+		}
+	})
+
+	t.Run("test old versions are unchanged", func(t *testing.T) {
+		versions := []*PersistentMap[int, string]{NewPersistentMap[int, string]()}
+		expected := [][]string{{}}
+
+		// every version adds one key, updates another and removes a third
+		current := map[int]string{}
+		for i := 0; i < 50; i++ {
+			m := versions[len(versions)-1]
+			m = m.Insert(i, fmt.Sprint(i))
+			current[i] = fmt.Sprint(i)
+
+			m = m.Insert(i/2, fmt.Sprintf("updated-%d", i))
+			current[i/2] = fmt.Sprintf("updated-%d", i)
+
+			if i%3 == 0 {
+				m, _ = m.Delete(i / 3)
+				delete(current, i/3)
+			}
+
+			versions = append(versions, m)
+			snapshot := make([]string, 0)
+			for key := 0; key < 50; key++ {
+				if value, ok := current[key]; ok {
+					snapshot = append(snapshot, fmt.Sprintf("%d=%s", key, value))
+				}
+			}
+			expected = append(expected, snapshot)
+		}
+
+		for i, m := range versions {
+			verifyPersistentNode(t, m, m.root)
+			if pairs := collectPairs(m.ForwardIterator()); !slices.Equal(pairs, expected[i]) {
+				t.Errorf("Version %d changed: expected %v, got %v", i, expected[i], pairs)
+			}
+
+			if m.Size() != len(expected[i]) {
+				t.Errorf("Version %d: expected size %d, got %d", i, len(expected[i]), m.Size())
+			}
+		}
+	})
+
+	t.Run("test iterators and ranges", func(t *testing.T) {
+		m := NewPersistentMap[int, string]()
+		for i := 0; i < 100; i += 10 {
+			m = m.Insert(i, fmt.Sprint(i))
+		}
+
+		testCases := []struct {
+			name     string
+			seq      iter.Seq2[int, string]
+			expected []string
+		}{
+			{"forward", m.ForwardIterator(), []string{"0=0", "10=10", "20=20", "30=30", "40=40", "50=50", "60=60", "70=70", "80=80", "90=90"}},
+			{"backward", m.BackwardIterator(), []string{"90=90", "80=80", "70=70", "60=60", "50=50", "40=40", "30=30", "20=20", "10=10", "0=0"}},
+			{"range inclusive", m.Range(20, 50), []string{"20=20", "30=30", "40=40", "50=50"}},
+			{"range between keys", m.Range(15, 55), []string{"20=20", "30=30", "40=40", "50=50"}},
+			{"range exclusive", m.Range(20, 50, ExcludeLow, ExcludeHigh), []string{"30=30", "40=40"}},
+			{"range empty", m.Range(21, 29), []string{}},
+			{"range desc inclusive", m.RangeDesc(50, 20), []string{"50=50", "40=40", "30=30", "20=20"}},
+			{"range desc exclusive", m.RangeDesc(50, 20, ExcludeLow, ExcludeHigh), []string{"40=40", "30=30"}},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				if pairs := collectPairs(tc.seq); !slices.Equal(pairs, tc.expected) {
+					t.Errorf("Expected %v, got %v", tc.expected, pairs)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,321 @@
+package trees
+
+/*
+	1. Radix tree is a compressed trie, a chain of nodes with a single child and no value
+	   is merged into one node holding the whole chain as it's prefix.
+	2. It needs far fewer nodes than a Trie when keys share long prefixes, like URL paths.
+	3. Units are either bytes or runes, same as Trie. Rune-wise trees only split prefixes
+	   on rune boundaries, invalid bytes are stored as utf8.RuneError.
+	4. Children are kept sorted by their first unit, so iteration is in the same order as comparing keys with '<'.
+*/
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+type radixNode[V any] struct {
+	prefix   string
+	value    V
+	hasValue bool
+	children []*radixNode[V]
+}
+
+type RadixTree[V any] struct {
+	root     *radixNode[V]
+	runes    bool
+	treeSize int
+}
+
+// Returns a pointer to an instance of a RadixTree struct.
+// Keys are split into bytes.
+func NewRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{
+		root:     &radixNode[V]{},
+		runes:    false,
+		treeSize: 0,
+	}
+}
+
+// Returns a pointer to an instance of a RadixTree struct.
+// Keys are split into runes.
+func NewRuneRadixTree[V any]() *RadixTree[V] {
+	return &RadixTree[V]{
+		root:     &radixNode[V]{},
+		runes:    true,
+		treeSize: 0,
+	}
+}
+
+// Inserts a key-value pair into the RadixTree.
+// If the key already exists, it's value is updated.
+func (t *RadixTree[V]) Insert(key string, value V) {
+	key = t.normalize(key)
+	node := t.root
+
+	for len(key) > 0 {
+		i, found := t.find(node, key)
+		if !found {
+			// nothing shares the first unit, the rest of the key becomes a new leaf
+			leaf := &radixNode[V]{prefix: key, value: value, hasValue: true}
+			node.children = slices.Insert(node.children, i, leaf)
+			t.treeSize++
+			return
+		}
+
+		child := node.children[i]
+		common := t.commonPrefix(child.prefix, key)
+		if common < len(child.prefix) {
+			// key leaves the child's prefix half way, split the child at that point
+			split := &radixNode[V]{prefix: child.prefix[:common], children: []*radixNode[V]{child}}
+			child.prefix = child.prefix[common:]
+			node.children[i] = split
+			child = split
+		}
+
+		node = child
+		key = key[common:]
+	}
+
+	if !node.hasValue {
+		t.treeSize++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+// Nodes are merged back together where the key was the only reason to keep them apart.
+func (t *RadixTree[V]) Delete(key string) bool {
+	key = t.normalize(key)
+	var parent *radixNode[V]
+	node := t.root
+
+	for len(key) > 0 {
+		i, found := t.find(node, key)
+		if !found || !strings.HasPrefix(key, node.children[i].prefix) {
+			return false
+		}
+		parent, node = node, node.children[i]
+		key = key[len(node.prefix):]
+	}
+
+	if !node.hasValue {
+		return false
+	}
+
+	node.value = *new(V)
+	node.hasValue = false
+	t.treeSize--
+
+	if node == t.root {
+		return true
+	}
+
+	switch len(node.children) {
+	case 0:
+		i, _ := t.find(parent, node.prefix)
+		parent.children = slices.Delete(parent.children, i, i+1)
+		// parent might be left as a plain link between it's parent and a single child
+		if parent != t.root && !parent.hasValue && len(parent.children) == 1 {
+			parent.mergeChild()
+		}
+	case 1:
+		node.mergeChild()
+	}
+	return true
+}
+
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (t *RadixTree[V]) Get(key string) (V, bool) {
+	key = t.normalize(key)
+	node := t.root
+
+	for len(key) > 0 {
+		i, found := t.find(node, key)
+		if !found || !strings.HasPrefix(key, node.children[i].prefix) {
+			return *new(V), false
+		}
+		node = node.children[i]
+		key = key[len(node.prefix):]
+	}
+
+	return node.value, node.hasValue
+}
+
+// Returns the longest stored key which is a prefix of the given key, and it's value.
+// Boolean is false if no stored key is a prefix of the key.
+func (t *RadixTree[V]) LongestPrefixMatch(key string) (string, V, bool) {
+	key = t.normalize(key)
+	node := t.root
+	var match *radixNode[V]
+	matched := 0
+	if node.hasValue {
+		match = node
+	}
+
+	for consumed := 0; consumed < len(key); {
+		rest := key[consumed:]
+		i, found := t.find(node, rest)
+		if !found || !strings.HasPrefix(rest, node.children[i].prefix) {
+			break
+		}
+		node = node.children[i]
+		consumed += len(node.prefix)
+
+		if node.hasValue {
+			match, matched = node, consumed
+		}
+	}
+
+	if match == nil {
+		return "", *new(V), false
+	}
+	return key[:matched], match.value, true
+}
+
+// Returns a 'push' iterator over all keys starting with the given prefix.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in sorted ordering.
+func (t *RadixTree[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	prefix = t.normalize(prefix)
+
+	return func(yield func(string, V) bool) {
+		node := t.root
+		key := make([]byte, 0, len(prefix))
+
+		for rest := prefix; len(rest) > 0; {
+			i, found := t.find(node, rest)
+			if !found {
+				return
+			}
+
+			child := node.children[i]
+			if strings.HasPrefix(rest, child.prefix) {
+				rest = rest[len(child.prefix):]
+			} else if strings.HasPrefix(child.prefix, rest) {
+				// prefix ends inside the child's prefix, so every key below the child matches
+				rest = ""
+			} else {
+				return
+			}
+
+			node = child
+			key = append(key, child.prefix...)
+		}
+
+		t.walk(node, key, false, yield)
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in sorted ordering.
+func (t *RadixTree[V]) ForwardIterator() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.walk(t.root, nil, false, yield)
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (t *RadixTree[V]) BackwardIterator() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.walk(t.root, nil, true, yield)
+	}
+}
+
+// Returns true if tree is empty, otherwise false.
+func (t *RadixTree[V]) IsEmpty() bool {
+	return t.treeSize == 0
+}
+
+// Returns the current number of keys in the tree.
+func (t *RadixTree[V]) Size() int {
+	return t.treeSize
+}
+
+// Clears and resets the tree to an empty tree.
+func (t *RadixTree[V]) Clear() {
+	t.root = &radixNode[V]{}
+	t.treeSize = 0
+}
+
+// Yields all keys in the subtree rooted at 'node', 'key' holds the path to the node.
+// Returns false if iteration was stopped.
+func (t *RadixTree[V]) walk(node *radixNode[V], key []byte, reverse bool, yield func(string, V) bool) bool {
+	// a key comes before all keys it is a prefix of
+	if !reverse && node.hasValue && !yield(string(key), node.value) {
+		return false
+	}
+
+	for i := range node.children {
+		child := node.children[i]
+		if reverse {
+			child = node.children[len(node.children)-1-i]
+		}
+		if !t.walk(child, append(key, child.prefix...), reverse, yield) {
+			return false
+		}
+	}
+
+	if reverse && node.hasValue && !yield(string(key), node.value) {
+		return false
+	}
+	return true
+}
+
+// Returns the index of the child whose prefix starts with the same unit as the non empty key,
+// or where it would be inserted, and whether that child exists.
+func (t *RadixTree[V]) find(node *radixNode[V], key string) (int, bool) {
+	unit, _ := nextKeyUnit(key, t.runes)
+	return slices.BinarySearchFunc(node.children, unit, func(child *radixNode[V], unit rune) int {
+		first, _ := nextKeyUnit(child.prefix, t.runes)
+		return cmp.Compare(first, unit)
+	})
+}
+
+// Returns the length in bytes of the longest common prefix of a and b.
+// Rune-wise trees never cut a rune in half.
+func (t *RadixTree[V]) commonPrefix(a, b string) int {
+	length := 0
+	for length < len(a) && length < len(b) && a[length] == b[length] {
+		length++
+	}
+
+	if t.runes {
+		for length > 0 && ((length < len(a) && !utf8.RuneStart(a[length])) || (length < len(b) && !utf8.RuneStart(b[length]))) {
+			length--
+		}
+	}
+	return length
+}
+
+// Replaces every invalid byte of a key with utf8.RuneError in rune-wise trees,
+// the same way ranging over the string decodes it.
+func (t *RadixTree[V]) normalize(key string) string {
+	if !t.runes || utf8.ValidString(key) {
+		return key
+	}
+
+	var builder strings.Builder
+	for _, r := range key {
+		builder.WriteRune(r)
+	}
+	return builder.String()
+}
+
+// Merges the only child of the node into the node.
+// The first unit of the prefix stays the same, so the node keeps it's place among it's siblings.
+func (n *radixNode[V]) mergeChild() {
+	child := n.children[0]
+	n.prefix += child.prefix
+	n.value, n.hasValue = child.value, child.hasValue
+	n.children = child.children
+}
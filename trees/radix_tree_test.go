@@ -0,0 +1,105 @@
+package trees
+
+import "testing"
+
+// Helper function to verify radix tree properties
+func verifyRadixTree[V any](t *testing.T, tree *RadixTree[V]) {
+	if count := verifyRadixNode(t, tree, tree.root); count != tree.Size() {
+		t.Errorf("Key count %d does not match tree size %d", count, tree.Size())
+	}
+}
+
+// Checks that nodes are fully compressed and children are sorted, returns the number of keys in the subtree.
+func verifyRadixNode[V any](t *testing.T, tree *RadixTree[V], node *radixNode[V]) int {
+	count := 0
+	if node.hasValue {
+		count++
+	}
+
+	if node != tree.root {
+		if node.prefix == "" {
+			t.Error("Only the root may have an empty prefix")
+		}
+		if !node.hasValue && len(node.children) < 2 {
+			t.Errorf("Node %q without value should have been merged, it has %d children", node.prefix, len(node.children))
+		}
+	}
+
+	for i, child := range node.children {
+		if i > 0 {
+			previous, _ := nextKeyUnit(node.children[i-1].prefix, tree.runes)
+			current, _ := nextKeyUnit(child.prefix, tree.runes)
+			if previous >= current {
+				t.Errorf("Children of %q out of order: %q before %q", node.prefix, node.children[i-1].prefix, child.prefix)
+			}
+		}
+		count += verifyRadixNode(t, tree, child)
+	}
+	return count
+}
+
+func TestRadixTreeSplitAndMerge(t *testing.T) {
+	tree := NewRadixTree[int]()
+
+	tree.Insert("romane", 1)
+	if len(tree.root.children) != 1 || tree.root.children[0].prefix != "romane" {
+		t.Fatal("Single key should be stored in a single node")
+	}
+
+	tree.Insert("romanus", 2)
+	split := tree.root.children[0]
+	if split.prefix != "roman" || len(split.children) != 2 {
+		t.Fatalf("Expected split at %q with 2 children, got %q with %d", "roman", split.prefix, len(split.children))
+	}
+	if split.children[0].prefix != "e" || split.children[1].prefix != "us" {
+		t.Errorf("Unexpected children %q and %q", split.children[0].prefix, split.children[1].prefix)
+	}
+
+	tree.Insert("rom", 3)
+	tree.Insert("rubicon", 4)
+	verifyRadixTree(t, tree)
+
+	// removing a key merges it's parent back with the only remaining child
+	tree.Delete("romane")
+	verifyRadixTree(t, tree)
+	tree.Delete("rom")
+	verifyRadixTree(t, tree)
+
+	if value, ok := tree.Get("romanus"); !ok || value != 2 {
+		t.Errorf("Get(romanus) = %d, %v after merges", value, ok)
+	}
+	if _, ok := tree.Get("roman"); ok {
+		t.Error("Inner split point should not be a key")
+	}
+	if tree.Delete("roman") {
+		t.Error("Deleting an inner split point should return false")
+	}
+}
+
+func TestRadixTreeRuneBoundaries(t *testing.T) {
+	byteTree, runeTree := NewRadixTree[int](), NewRuneRadixTree[int]()
+
+	// both keys start with the same lead byte 0xc3
+	for _, tree := range []*RadixTree[int]{byteTree, runeTree} {
+		tree.Insert("é", 1)
+		tree.Insert("è", 2)
+		verifyRadixTree(t, tree)
+	}
+
+	if len(byteTree.root.children) != 1 || byteTree.root.children[0].prefix != "\xc3" {
+		t.Error("Byte-wise tree should share the common lead byte")
+	}
+	if len(runeTree.root.children) != 2 {
+		t.Errorf("Rune-wise tree should not split inside a rune, got %d children", len(runeTree.root.children))
+	}
+
+	// invalid bytes are stored as utf8.RuneError in rune-wise trees
+	runeTree.Insert("a\xff", 3)
+	if value, ok := runeTree.Get("a�"); !ok || value != 3 {
+		t.Errorf("Expected invalid byte to be stored as RuneError, got %d, %v", value, ok)
+	}
+	if value, ok := byteTree.Get("a\xff"); ok {
+		t.Errorf("Byte-wise tree should not have the key yet, got %d", value)
+	}
+	verifyRadixTree(t, runeTree)
+}
@@ -15,8 +15,11 @@ package trees
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
+	"io"
 	"iter"
+	"strings"
 )
 
 type Color string
@@ -26,28 +29,62 @@ const (
 	BLACK Color = "black"
 )
 
-type RedBlackTreeNode[T cmp.Ordered, V any] struct {
+var (
+	ErrUnsortedInput   = errors.New("input keys are not sorted")
+	ErrDuplicateKey    = errors.New("input keys contain a duplicate")
+	ErrLengthMismatch  = errors.New("number of keys and values do not match")
+	ErrOverlappingKeys = errors.New("key ranges of the trees overlap")
+	ErrInvalidTree     = errors.New("red black tree is invalid")
+)
+
+// Controls whether the bounds of a range query are part of the range.
+// By default both bounds are inclusive, options can be combined.
+type RangeOption uint8
+
+const (
+	ExcludeLow  RangeOption = 1 << iota // lower bound is not part of the range
+	ExcludeHigh                         // upper bound is not part of the range
+)
+
+type RedBlackTreeNode[T any, V any] struct {
 	Key       T
 	Value     V
 	NodeColor Color
 	Left      *RedBlackTreeNode[T, V]
 	Right     *RedBlackTreeNode[T, V]
 	Parent    *RedBlackTreeNode[T, V]
+	// number of nodes in the subtree rooted at this node, NIL node always has size 0
+	subtreeSize int
 }
 
-type RedBlackTree[T cmp.Ordered, V any] struct {
+type RedBlackTree[T any, V any] struct {
 	Root     *RedBlackTreeNode[T, V]
 	NIL      *RedBlackTreeNode[T, V]
 	treeSize int
+	compare  func(a, b T) int
+	// optional hook which recomputes user defined data of a node from it's children,
+	// called everywhere subtree sizes are recomputed, see AugmentedTree
+	augment func(node *RedBlackTreeNode[T, V])
 }
 
-type iterator[T cmp.Ordered, V any] struct {
+// Cursor points at a single node of a RedBlackTree, similar to a C++ std::map iterator.
+// A cursor which has moved past either end of the tree is no longer valid.
+type Cursor[T any, V any] struct {
 	current *RedBlackTreeNode[T, V]
 	tree    *RedBlackTree[T, V]
 }
 
 // Returns a pointer to an instance of a RedBlackTree struct.
+// Works with default built in types.
 func NewRedBlackTree[T cmp.Ordered, V any]() *RedBlackTree[T, V] {
+	return NewRedBlackTreeWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an instance of a RedBlackTree struct.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys.
+// The comparator must return a negative number if a < b, zero if a == b and a positive number if a > b.
+func NewRedBlackTreeWithFunc[T any, V any](comparator func(a, b T) int) *RedBlackTree[T, V] {
 	nilNode := &RedBlackTreeNode[T, V]{
 		NodeColor: BLACK,
 	}
@@ -56,19 +93,21 @@ func NewRedBlackTree[T cmp.Ordered, V any]() *RedBlackTree[T, V] {
 		Root:     nilNode,
 		NIL:      nilNode,
 		treeSize: 0,
+		compare:  comparator,
 	}
 }
 
 // Inserts a key-value pair into the RedBlackTree.
-// The key has to be of type cmp.Ordered, value can be anything.
+// The key is ordered by the tree's comparator, value can be anything.
 func (t *RedBlackTree[T, V]) Insert(key T, value V) {
 	newNode := &RedBlackTreeNode[T, V]{
-		NodeColor: RED,
-		Key:       key,
-		Value:     value,
-		Left:      t.NIL,
-		Right:     t.NIL,
-		Parent:    t.NIL,
+		NodeColor:   RED,
+		Key:         key,
+		Value:       value,
+		Left:        t.NIL,
+		Right:       t.NIL,
+		Parent:      t.NIL,
+		subtreeSize: 1,
 	}
 
 	currentNode := t.Root
@@ -90,14 +129,19 @@ func (t *RedBlackTree[T, V]) Insert(key T, value V) {
 			 |
 			(currentNode) Traverse tree using this node
 		*/
-		if newNode.Key < currentNode.Key {
+		result := t.compare(newNode.Key, currentNode.Key)
+		if result < 0 {
 			currentNode = currentNode.Left
-		} else if newNode.Key > currentNode.Key {
+		} else if result > 0 {
 			currentNode = currentNode.Right
 		} else {
 			// if exact key is found
 			// update the value and return, nothing left to do
 			currentNode.Value = value
+			if t.augment != nil {
+				// augmented data may depend on the value
+				t.updateSubtreeSizes(currentNode)
+			}
 			return
 		}
 	}
@@ -110,21 +154,35 @@ func (t *RedBlackTree[T, V]) Insert(key T, value V) {
 	if parentNode == t.NIL {
 		// is parent is nil, insert new node at root
 		t.Root = newNode
-	} else if newNode.Key < parentNode.Key {
+	} else if t.compare(newNode.Key, parentNode.Key) < 0 {
 		parentNode.Left = newNode
-	} else if newNode.Key > parentNode.Key {
+	} else {
 		parentNode.Right = newNode
 	}
 
+	// every ancestor of the new node now has one more node in it's subtree
+	t.updateSubtreeSizes(newNode)
+
 	// after insertion, call insert fixup helper to maintain red black tree properties
 	t.insertFixup(newNode)
 	t.treeSize++
 }
 
 // Deletes a key from the tree.
-// Key has to be of type cmp.Ordered
 // If key does not exist, returns false, otherwise true if deletion is successful.
 func (t *RedBlackTree[T, V]) Delete(key T) bool {
+	nodeToBeDeleted, ok := t.Search(key)
+	if !ok {
+		return false // node with given key does not exist on the tree
+	}
+
+	t.deleteNode(nodeToBeDeleted)
+	return true
+}
+
+// Removes the given node from the tree.
+// The node must belong to the tree and must not be the NIL node.
+func (t *RedBlackTree[T, V]) deleteNode(nodeToBeDeleted *RedBlackTreeNode[T, V]) {
 	/*
 		For deletion we need to consider these cases
 		1. Left child of node to be deleted is NIL
@@ -135,23 +193,23 @@ func (t *RedBlackTree[T, V]) Delete(key T) bool {
 		If we deleted a node with original color as RED, NO FIXUP is needed.
 		Since deleting a red node does not reduce the number of black nodes on any path
 		Fixup is called ONLY for deleting node which have BLACK color
-	*/
 
-	nodeToBeDeleted, ok := t.Search(key)
-	if !ok {
-		return false // node with given key does not exist on the tree
-	}
+		The NIL node is never written to, so the parent of the replacement node is tracked separately.
+		This keeps the NIL node safe to share between trees, see Split and Join.
+	*/
 
 	originalNode := nodeToBeDeleted
 	originalNodeColor := nodeToBeDeleted.NodeColor
-	var replacementNode *RedBlackTreeNode[T, V]
+	var replacementNode, replacementParent *RedBlackTreeNode[T, V]
 
 	if originalNode.Left == t.NIL {
 		// if left child is NIL, transplant this node with it's right child
 		replacementNode = originalNode.Right
+		replacementParent = originalNode.Parent
 		t.transplant(originalNode, originalNode.Right)
 	} else if originalNode.Right == t.NIL {
 		replacementNode = originalNode.Left
+		replacementParent = originalNode.Parent
 		t.transplant(originalNode, originalNode.Left)
 	} else {
 		// if neither children are NIL
@@ -184,8 +242,9 @@ func (t *RedBlackTree[T, V]) Delete(key T) bool {
 		*/
 
 		if successor.Parent == nodeToBeDeleted {
-			replacementNode.Parent = successor
+			replacementParent = successor
 		} else {
+			replacementParent = successor.Parent
 			t.transplant(successor, successor.Right) // transplant successor with it's right child, which would be NIL child
 			successor.Right = nodeToBeDeleted.Right
 			successor.Right.Parent = successor
@@ -198,26 +257,28 @@ func (t *RedBlackTree[T, V]) Delete(key T) bool {
 		successor.NodeColor = nodeToBeDeleted.NodeColor // keep the color same
 	}
 
+	// subtree sizes are stale from the lowest modified node up to the root
+	t.updateSubtreeSizes(replacementParent)
+
 	if originalNodeColor == BLACK {
 		// fixup is only needed when deleting a black node, if you delete a red node the number of black nodes per path does not change
 		// hence no fixup needed for RED node deletions
-		t.deleteFixup(replacementNode)
+		t.deleteFixup(replacementNode, replacementParent)
 	}
 	t.treeSize--
-	return true
 }
 
 // Searches for a key in the tree.
-// Key has to be of type cmp.Ordered
 // Returns the node and boolean value.
 // Boolean is true if key is found, otherwise false.
 func (t *RedBlackTree[T, V]) Search(key T) (*RedBlackTreeNode[T, V], bool) {
 	currentNode := t.Root
 
 	for currentNode != t.NIL {
-		if currentNode.Key == key {
+		result := t.compare(key, currentNode.Key)
+		if result == 0 {
 			return currentNode, true
-		} else if key < currentNode.Key {
+		} else if result < 0 {
 			currentNode = currentNode.Left
 		} else {
 			currentNode = currentNode.Right
@@ -227,6 +288,13 @@ func (t *RedBlackTree[T, V]) Search(key T) (*RedBlackTreeNode[T, V], bool) {
 	return t.NIL, false
 }
 
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (t *RedBlackTree[T, V]) Get(key T) (V, bool) {
+	node, ok := t.Search(key)
+	return node.Value, ok
+}
+
 // Returns true if tree is empty, otherwise false.
 func (t *RedBlackTree[T, V]) IsEmpty() bool {
 	return t.Root == t.NIL
@@ -240,81 +308,273 @@ func (t *RedBlackTree[T, V]) Size() int {
 // Clears and resets the tree to an empty tree.
 func (t *RedBlackTree[T, V]) Clear() {
 	t.Root = t.NIL
+	t.treeSize = 0
 }
 
-// Prints the key value pairs in the tree.
-// The ordering is 'InOrder' sorted ordering.
-func (t *RedBlackTree[T, V]) PrintTree() {
-	// Using Morris Traversal to preserve memory space
+// Returns the k-th smallest node in the tree, k starts from 0.
+// Boolean is true if such node exists, otherwise false.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Select(k int) (*RedBlackTreeNode[T, V], bool) {
+	if k < 0 || k >= t.treeSize {
+		return t.NIL, false
+	}
+
 	currentNode := t.Root
 	for currentNode != t.NIL {
-		// check if left child exists
-		if currentNode.Left == t.NIL {
-			// No left child, print current node value.
-			// Move on to right child.
-			fmt.Printf("KEY: %v, VALUE: %v, NODE COLOR: %v\n", currentNode.Key, currentNode.Value, currentNode.NodeColor)
+		leftSize := currentNode.Left.subtreeSize
+		if k < leftSize {
+			currentNode = currentNode.Left
+		} else if k > leftSize {
+			// skip the left subtree and the current node
+			k -= leftSize + 1
 			currentNode = currentNode.Right
 		} else {
-			// left child exists
-			// find inorder predecessor
-			// meaning right most child of left child
-			predecessor := currentNode.Left
-			for predecessor.Right != t.NIL && predecessor.Right != currentNode {
-				predecessor = predecessor.Right
-			}
+			return currentNode, true
+		}
+	}
 
-			switch predecessor.Right {
-			case t.NIL:
-				predecessor.Right = currentNode
-				currentNode = currentNode.Left
-			case currentNode:
-				predecessor.Right = t.NIL
-				fmt.Printf("KEY: %v, VALUE: %v, NODE COLOR: %v\n", currentNode.Key, currentNode.Value, currentNode.NodeColor)
-				currentNode = currentNode.Right
-			}
+	return t.NIL, false
+}
+
+// Returns the number of keys in the tree strictly smaller than the given key.
+// The key does not need to exist in the tree.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Rank(key T) int {
+	rank := 0
+	currentNode := t.Root
+
+	for currentNode != t.NIL {
+		if t.compare(key, currentNode.Key) <= 0 {
+			currentNode = currentNode.Left
+		} else {
+			// current node and it's entire left subtree are smaller than key
+			rank += currentNode.Left.subtreeSize + 1
+			currentNode = currentNode.Right
 		}
 	}
+
+	return rank
 }
 
-// Returns a 'push' iterator to the tree.
-// Works with 'for range' expression.
-// Returns key-value pair per iteration in 'InOrder' sorted ordering.
-// Values are returned starting from the first item.
-func (t *RedBlackTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
-	type Pair struct {
-		key   T
-		value V
+// Returns the number of keys in the tree which lie in the closed interval [lo, hi].
+// Returns 0 if lo is greater than hi.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) CountRange(lo, hi T) int {
+	if t.compare(lo, hi) > 0 {
+		return 0
 	}
 
-	result := make([]Pair, 0, t.treeSize)
+	count := t.Rank(hi) - t.Rank(lo)
+	if _, ok := t.Search(hi); ok {
+		count++
+	}
+	return count
+}
 
-	// Use Morris Traversal for space efficient traversal
-	currentNode := t.Root
-	for currentNode != t.NIL {
-		if currentNode.Left == t.NIL {
-			result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
-			currentNode = currentNode.Right
-		} else {
-			inOrderPredecessor := currentNode.Left
-			for inOrderPredecessor.Right != t.NIL && inOrderPredecessor.Right != currentNode {
-				inOrderPredecessor = inOrderPredecessor.Right
-			}
+// Replaces the contents of the tree with the given key-value pairs.
+// Keys must be sorted in strictly ascending order, keys[i] is paired with values[i].
+// Builds the tree in O(n) time instead of O(n log n) for repeated inserts.
+// Returns an error if input is unsorted, contains duplicates or lengths don't match, the tree is left unchanged in that case.
+func (t *RedBlackTree[T, V]) FromSorted(keys []T, values []V) error {
+	if len(keys) != len(values) {
+		return fmt.Errorf("%w: %d keys, %d values", ErrLengthMismatch, len(keys), len(values))
+	}
 
-			switch inOrderPredecessor.Right {
-			case t.NIL:
-				inOrderPredecessor.Right = currentNode
-				currentNode = currentNode.Left
-			case currentNode:
-				inOrderPredecessor.Right = t.NIL
-				result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
-				currentNode = currentNode.Right
-			}
+	for i := 1; i < len(keys); i++ {
+		result := t.compare(keys[i-1], keys[i])
+		if result > 0 {
+			return fmt.Errorf("%w: key %v at index %d is smaller than previous key %v", ErrUnsortedInput, keys[i], i, keys[i-1])
+		} else if result == 0 {
+			return fmt.Errorf("%w: key %v at index %d", ErrDuplicateKey, keys[i], i)
+		}
+	}
+
+	/*
+		A tree built by always picking the middle element as root is perfectly balanced,
+		every NIL leaf lies on one of the two deepest levels.
+		Coloring the deepest level of nodes RED and everything above BLACK
+		gives the same number of black nodes on every path without any two consecutive red nodes.
+	*/
+	redDepth := -1 // a single node tree has only the root, which must stay black
+	if len(keys) > 1 {
+		redDepth = 0
+		for size := len(keys); size > 1; size /= 2 {
+			redDepth++
 		}
 	}
 
+	t.Root = t.buildSorted(keys, values, t.NIL, 0, redDepth)
+	t.treeSize = len(keys)
+	return nil
+}
+
+// Replaces the contents of the tree with the key-value pairs produced by the iterator.
+// Same rules as FromSorted apply, keys must be produced in strictly ascending order.
+func (t *RedBlackTree[T, V]) FromSeq(seq iter.Seq2[T, V]) error {
+	keys := make([]T, 0)
+	values := make([]V, 0)
+
+	for key, value := range seq {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+
+	return t.FromSorted(keys, values)
+}
+
+// Splits the tree into two trees at the given key.
+// Left tree contains all keys smaller than key, right tree contains all keys greater than or equal to key.
+// Nodes are moved, not copied, so the original tree is empty afterwards.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Split(key T) (*RedBlackTree[T, V], *RedBlackTree[T, V]) {
+	leftRoot, _, rightRoot, _ := t.split(t.Root, t.blackHeight(t.Root), key)
+
+	// both halves share the NIL node of the original tree, which makes joining them back O(log n)
+	left := &RedBlackTree[T, V]{Root: leftRoot, NIL: t.NIL, treeSize: leftRoot.subtreeSize, compare: t.compare, augment: t.augment}
+	right := &RedBlackTree[T, V]{Root: rightRoot, NIL: t.NIL, treeSize: rightRoot.subtreeSize, compare: t.compare, augment: t.augment}
+
+	t.Clear()
+	return left, right
+}
+
+// Moves all nodes of the other tree into this tree.
+// Every key of this tree must be smaller than every key of the other tree.
+// Returns an error if the key ranges overlap, both trees are left unchanged in that case.
+// The other tree is empty afterwards.
+// Runs in O(log n) time for trees produced by Split of the same tree.
+// Otherwise the other tree's nodes need to be re-linked to this tree's NIL node first, which takes O(m) time.
+func (t *RedBlackTree[T, V]) Join(other *RedBlackTree[T, V]) error {
+	if other.IsEmpty() {
+		return nil
+	}
+
+	if !t.IsEmpty() {
+		largest, smallest := t.maximum(t.Root), other.minimum(other.Root)
+		if t.compare(largest.Key, smallest.Key) >= 0 {
+			return fmt.Errorf("%w: key %v is not smaller than key %v", ErrOverlappingKeys, largest.Key, smallest.Key)
+		}
+	}
+
+	if other.NIL != t.NIL {
+		other.adoptNodes(other.Root, t.NIL)
+		other.NIL = t.NIL
+	}
+
+	// the smallest node of the other tree is used as the separating node
+	middle := other.minimum(other.Root)
+	other.deleteNode(middle)
+
+	t.Root, _ = t.join(t.Root, t.blackHeight(t.Root), middle, other.Root, other.blackHeight(other.Root))
+	t.treeSize = t.Root.subtreeSize
+
+	// other tree keeps it's own NIL node from now on, so it's independent of this tree
+	other.NIL = &RedBlackTreeNode[T, V]{NodeColor: BLACK}
+	other.Clear()
+	return nil
+}
+
+// Checks the structural invariants of the tree.
+// Verifies root color, no two consecutive red nodes, equal black height on all paths, key ordering,
+// parent pointers, NIL node integrity, subtree sizes and that Size() matches the number of nodes.
+// Returns nil if the tree is valid, otherwise an error wrapping ErrInvalidTree naming the offending key.
+// Runs in O(n) time, meant for tests and debugging.
+func (t *RedBlackTree[T, V]) Validate() error {
+	if t.NIL == nil {
+		return fmt.Errorf("%w: NIL node is missing", ErrInvalidTree)
+	}
+
+	// the NIL node is never written to, any change means something corrupted it
+	if t.NIL.NodeColor != BLACK {
+		return fmt.Errorf("%w: NIL node is %v, expected black", ErrInvalidTree, t.NIL.NodeColor)
+	}
+	if t.NIL.Left != nil || t.NIL.Right != nil || t.NIL.Parent != nil {
+		return fmt.Errorf("%w: NIL node is linked to other nodes", ErrInvalidTree)
+	}
+	if t.NIL.subtreeSize != 0 {
+		return fmt.Errorf("%w: NIL node has subtree size %d, expected 0", ErrInvalidTree, t.NIL.subtreeSize)
+	}
+
+	if t.Root == nil {
+		return fmt.Errorf("%w: root is nil, expected NIL node", ErrInvalidTree)
+	}
+
+	if t.Root != t.NIL {
+		if t.Root.NodeColor != BLACK {
+			return fmt.Errorf("%w: root %v is %v, expected black", ErrInvalidTree, t.Root.Key, t.Root.NodeColor)
+		}
+		if t.Root.Parent != t.NIL {
+			return fmt.Errorf("%w: root %v has a parent", ErrInvalidTree, t.Root.Key)
+		}
+	}
+
+	count, _, err := t.validateNode(t.Root, nil, nil)
+	if err != nil {
+		return err
+	}
+
+	if count != t.treeSize {
+		return fmt.Errorf("%w: tree size %d does not match node count %d", ErrInvalidTree, t.treeSize, count)
+	}
+	return nil
+}
+
+// Prints the key value pairs in the tree.
+// The ordering is 'InOrder' sorted ordering.
+// Nodes are visited using parent pointers same as ForwardIterator, so the tree is never modified.
+func (t *RedBlackTree[T, V]) PrintTree() {
+	for node := t.minimum(t.Root); node != t.NIL; node = t.inorderSuccessor(node) {
+		fmt.Printf("KEY: %v, VALUE: %v, NODE COLOR: %v\n", node.Key, node.Value, node.NodeColor)
+	}
+}
+
+// Writes the tree as a Graphviz DOT graph to w.
+// Nodes are filled with their color, NIL leaves are drawn as small points so left and right children can be told apart.
+// Render with: dot -Tpng tree.dot -o tree.png
+func (t *RedBlackTree[T, V]) WriteDOT(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph RedBlackTree {"); err != nil {
+		return err
+	}
+
+	if _, err := fmt.Fprintln(w, "\tnode [style=filled, fontcolor=white];"); err != nil {
+		return err
+	}
+
+	id := 0
+	if _, err := t.writeDOTNode(w, t.Root, &id); err != nil {
+		return err
+	}
+
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
+
+// Writes the tree to w drawn sideways, root on the left and the right subtree on top.
+// Every node is labelled with it's key and color, [R] for red and [B] for black.
+func (t *RedBlackTree[T, V]) WriteASCII(w io.Writer) error {
+	if t.Root == t.NIL {
+		_, err := fmt.Fprintln(w, "<empty>")
+		return err
+	}
+
+	return t.writeASCIINode(w, t.Root, "", "", "")
+}
+
+// Returns the tree drawn sideways, same as WriteASCII.
+func (t *RedBlackTree[T, V]) String() string {
+	var builder strings.Builder
+	t.WriteASCII(&builder) // writing to a strings.Builder never fails
+	return builder.String()
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+// Values are returned starting from the first item.
+// Nodes are visited lazily using parent pointers, so breaking early is cheap and the tree is never modified.
+func (t *RedBlackTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
 	return func(yield func(T, V) bool) {
-		for _, pair := range result {
-			if !yield(pair.key, pair.value) {
+		for node := t.minimum(t.Root); node != t.NIL; node = t.inorderSuccessor(node) {
+			if !yield(node.Key, node.Value) {
 				return
 			}
 		}
@@ -325,80 +585,320 @@ func (t *RedBlackTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
 // Works with 'for range' expression.
 // Returns key-value pair per iteration in 'InOrder' sorted ordering.
 // Values are returned from the starting from the last item.
+// Nodes are visited lazily using parent pointers, so breaking early is cheap and the tree is never modified.
 func (t *RedBlackTree[T, V]) BackwardIterator() iter.Seq2[T, V] {
-	type Pair struct {
-		key   T
-		value V
+	return func(yield func(T, V) bool) {
+		for node := t.maximum(t.Root); node != t.NIL; node = t.inOrderPredecessor(node) {
+			if !yield(node.Key, node.Value) {
+				return
+			}
+		}
 	}
+}
 
-	result := make([]Pair, 0, t.treeSize)
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in ascending order.
+// Both bounds are inclusive unless ExcludeLow or ExcludeHigh options are passed.
+// The iterator descends to the first key in O(log n) and streams the rest lazily.
+func (t *RedBlackTree[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
 
-	// Use Morris Traversal for space efficient traversal
-	currentNode := t.Root
-	for currentNode != t.NIL {
-		if currentNode.Left == t.NIL {
-			result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
-			currentNode = currentNode.Right
+	return func(yield func(T, V) bool) {
+		var start *Cursor[T, V]
+		if excludeLow {
+			start, _ = t.Higher(lo)
 		} else {
-			inOrderPredecessor := currentNode.Left
-			for inOrderPredecessor.Right != t.NIL && inOrderPredecessor.Right != currentNode {
-				inOrderPredecessor = inOrderPredecessor.Right
+			start, _ = t.Ceiling(lo)
+		}
+
+		for node := start.current; node != t.NIL; node = t.inorderSuccessor(node) {
+			result := t.compare(node.Key, hi)
+			if result > 0 || (result == 0 && excludeHigh) {
+				return
 			}
 
-			switch inOrderPredecessor.Right {
-			case t.NIL:
-				inOrderPredecessor.Right = currentNode
-				currentNode = currentNode.Left
-			case currentNode:
-				inOrderPredecessor.Right = t.NIL
-				result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
-				currentNode = currentNode.Right
+			if !yield(node.Key, node.Value) {
+				return
 			}
 		}
 	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in descending order, starting from hi.
+// Both bounds are inclusive unless ExcludeLow or ExcludeHigh options are passed.
+// The iterator descends to the first key in O(log n) and streams the rest lazily.
+func (t *RedBlackTree[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
 
 	return func(yield func(T, V) bool) {
-		for i := len(result) - 1; i >= 0; i-- {
-			if !yield(result[i].key, result[i].value) {
+		var start *Cursor[T, V]
+		if excludeHigh {
+			start, _ = t.Lower(hi)
+		} else {
+			start, _ = t.Floor(hi)
+		}
+
+		for node := start.current; node != t.NIL; node = t.inOrderPredecessor(node) {
+			result := t.compare(node.Key, lo)
+			if result < 0 || (result == 0 && excludeLow) {
+				return
+			}
+
+			if !yield(node.Key, node.Value) {
 				return
 			}
 		}
 	}
 }
 
-func (t *RedBlackTree[T, V]) Begin() *iterator[T, V] {
-	return &iterator[T, V]{
+// Returns a cursor positioned at the smallest key in the tree.
+// The cursor is not valid if the tree is empty.
+func (t *RedBlackTree[T, V]) Begin() *Cursor[T, V] {
+	return &Cursor[T, V]{
 		current: t.minimum(t.Root),
 		tree:    t,
 	}
 }
 
-func (t *RedBlackTree[T, V]) End() *iterator[T, V] {
-	return &iterator[T, V]{
+// Returns a cursor positioned at the largest key in the tree.
+// The cursor is not valid if the tree is empty.
+func (t *RedBlackTree[T, V]) End() *Cursor[T, V] {
+	return &Cursor[T, V]{
 		current: t.maximum(t.Root),
 		tree:    t,
 	}
 }
 
-func (it *iterator[T, V]) Next() {
-	if it.current == it.tree.NIL {
-		return
+// Returns a cursor positioned at the smallest key in the tree.
+// Boolean is false if the tree is empty.
+func (t *RedBlackTree[T, V]) Min() (*Cursor[T, V], bool) {
+	it := t.Begin()
+	return it, it.current != t.NIL
+}
+
+// Returns a cursor positioned at the largest key in the tree.
+// Boolean is false if the tree is empty.
+func (t *RedBlackTree[T, V]) Max() (*Cursor[T, V], bool) {
+	it := t.End()
+	return it, it.current != t.NIL
+}
+
+// Returns a cursor positioned at the largest key less than or equal to the given key.
+// Boolean is false if no such key exists.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Floor(key T) (*Cursor[T, V], bool) {
+	found := t.NIL
+	currentNode := t.Root
+
+	for currentNode != t.NIL {
+		result := t.compare(key, currentNode.Key)
+		if result == 0 {
+			found = currentNode
+			break
+		} else if result < 0 {
+			currentNode = currentNode.Left
+		} else {
+			// current node is a candidate, look for a bigger one on the right
+			found = currentNode
+			currentNode = currentNode.Right
+		}
 	}
-	it.current = it.tree.inorderSuccessor(it.current)
+
+	return &Cursor[T, V]{current: found, tree: t}, found != t.NIL
 }
 
-func (it *iterator[T, V]) Prev() {
-	if it.current == it.tree.NIL {
-		return
+// Returns a cursor positioned at the smallest key greater than or equal to the given key.
+// Boolean is false if no such key exists.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Ceiling(key T) (*Cursor[T, V], bool) {
+	found := t.NIL
+	currentNode := t.Root
+
+	for currentNode != t.NIL {
+		result := t.compare(key, currentNode.Key)
+		if result == 0 {
+			found = currentNode
+			break
+		} else if result > 0 {
+			currentNode = currentNode.Right
+		} else {
+			// current node is a candidate, look for a smaller one on the left
+			found = currentNode
+			currentNode = currentNode.Left
+		}
+	}
+
+	return &Cursor[T, V]{current: found, tree: t}, found != t.NIL
+}
+
+// Returns a cursor positioned at the largest key strictly less than the given key.
+// Boolean is false if no such key exists.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Lower(key T) (*Cursor[T, V], bool) {
+	found := t.NIL
+	currentNode := t.Root
+
+	for currentNode != t.NIL {
+		if t.compare(key, currentNode.Key) <= 0 {
+			currentNode = currentNode.Left
+		} else {
+			found = currentNode
+			currentNode = currentNode.Right
+		}
+	}
+
+	return &Cursor[T, V]{current: found, tree: t}, found != t.NIL
+}
+
+// Returns a cursor positioned at the smallest key strictly greater than the given key.
+// Boolean is false if no such key exists.
+// Runs in O(log n) time.
+func (t *RedBlackTree[T, V]) Higher(key T) (*Cursor[T, V], bool) {
+	found := t.NIL
+	currentNode := t.Root
+
+	for currentNode != t.NIL {
+		if t.compare(key, currentNode.Key) >= 0 {
+			currentNode = currentNode.Right
+		} else {
+			found = currentNode
+			currentNode = currentNode.Left
+		}
 	}
-	it.current = it.tree.inOrderPredecessor(it.current)
+
+	return &Cursor[T, V]{current: found, tree: t}, found != t.NIL
+}
+
+// Same as C++ std::map::lower_bound.
+// Returns a cursor positioned at the first key which is not less than the given key.
+func (t *RedBlackTree[T, V]) LowerBound(key T) (*Cursor[T, V], bool) {
+	return t.Ceiling(key)
+}
+
+// Same as C++ std::map::upper_bound.
+// Returns a cursor positioned at the first key which is greater than the given key.
+func (t *RedBlackTree[T, V]) UpperBound(key T) (*Cursor[T, V], bool) {
+	return t.Higher(key)
 }
 
-func (it *iterator[T, V]) Val() (T, V) {
-	return it.current.Key, it.current.Value
+// Removes the smallest key from the tree and returns it's key-value pair.
+// Boolean is false if the tree is empty.
+func (t *RedBlackTree[T, V]) PopMin() (T, V, bool) {
+	node := t.minimum(t.Root)
+	if node == t.NIL {
+		return *new(T), *new(V), false
+	}
+
+	key, value := node.Key, node.Value
+	t.deleteNode(node)
+	return key, value, true
 }
 
-func (t *RedBlackTree[T, V]) insertFixup(node *RedBlackTreeNode[T, V]) {
+// Removes the largest key from the tree and returns it's key-value pair.
+// Boolean is false if the tree is empty.
+func (t *RedBlackTree[T, V]) PopMax() (T, V, bool) {
+	node := t.maximum(t.Root)
+	if node == t.NIL {
+		return *new(T), *new(V), false
+	}
+
+	key, value := node.Key, node.Value
+	t.deleteNode(node)
+	return key, value, true
+}
+
+// Returns true if the cursor points at a node in the tree.
+func (c *Cursor[T, V]) Valid() bool {
+	return c.current != c.tree.NIL
+}
+
+// Moves the cursor to the smallest key greater than or equal to the given key.
+// Returns true if such key exists, otherwise the cursor becomes invalid.
+func (c *Cursor[T, V]) Seek(key T) bool {
+	found, _ := c.tree.Ceiling(key)
+	c.current = found.current
+	return c.Valid()
+}
+
+// Moves the cursor to the next key in sorted order.
+// Returns false if there is no next key, the cursor becomes invalid in that case.
+func (c *Cursor[T, V]) Next() bool {
+	if c.current == c.tree.NIL {
+		return false
+	}
+	c.current = c.tree.inorderSuccessor(c.current)
+	return c.Valid()
+}
+
+// Moves the cursor to the previous key in sorted order.
+// Returns false if there is no previous key, the cursor becomes invalid in that case.
+func (c *Cursor[T, V]) Prev() bool {
+	if c.current == c.tree.NIL {
+		return false
+	}
+	c.current = c.tree.inOrderPredecessor(c.current)
+	return c.Valid()
+}
+
+// Returns the key-value pair the cursor points at.
+// Returns zero values if the cursor is not valid.
+func (c *Cursor[T, V]) Val() (T, V) {
+	if c.current == c.tree.NIL {
+		return *new(T), *new(V)
+	}
+	return c.current.Key, c.current.Value
+}
+
+// Returns the key the cursor points at.
+// Returns zero value if the cursor is not valid.
+func (c *Cursor[T, V]) Key() T {
+	key, _ := c.Val()
+	return key
+}
+
+// Returns the value the cursor points at.
+// Returns zero value if the cursor is not valid.
+func (c *Cursor[T, V]) Value() V {
+	_, value := c.Val()
+	return value
+}
+
+// Replaces the value of the node the cursor points at.
+// Returns false if the cursor is not valid.
+func (c *Cursor[T, V]) SetValue(value V) bool {
+	if c.current == c.tree.NIL {
+		return false
+	}
+	c.current.Value = value
+	if c.tree.augment != nil {
+		c.tree.updateSubtreeSizes(c.current)
+	}
+	return true
+}
+
+// Same as C++ std::map::erase(it).
+// Deletes the node the cursor points at and moves the cursor to the next key.
+// Returns false if the cursor was not valid, nothing is deleted in that case.
+// Other cursors pointing at the deleted node become invalid to use.
+func (c *Cursor[T, V]) Erase() bool {
+	if c.current == c.tree.NIL {
+		return false
+	}
+
+	// deletion re-links nodes instead of copying keys around,
+	// so the successor node stays the same after the current node is removed
+	next := c.tree.inorderSuccessor(c.current)
+	c.tree.deleteNode(c.current)
+	c.current = next
+	return true
+}
+
+// Restores red black tree properties after 'node' has been linked in as a RED node.
+// Returns true if the root had to be recolored, which adds one black node to every path.
+func (t *RedBlackTree[T, V]) insertFixup(node *RedBlackTreeNode[T, V]) bool {
 	// 1. Check if newly inserted node's parent color is RED
 	// if not, then new node is the root node
 	for node.Parent.NodeColor == RED {
@@ -491,10 +991,12 @@ func (t *RedBlackTree[T, V]) insertFixup(node *RedBlackTreeNode[T, V]) {
 		}
 	}
 
+	grown := t.Root.NodeColor == RED
 	t.Root.NodeColor = BLACK // root is always black
+	return grown
 }
 
-func (t *RedBlackTree[T, V]) deleteFixup(node *RedBlackTreeNode[T, V]) {
+func (t *RedBlackTree[T, V]) deleteFixup(node, parent *RedBlackTreeNode[T, V]) {
 	/*
 		Fixes red black tree violations after deletion has taken place
 		We need to understand certain cases
@@ -506,77 +1008,83 @@ func (t *RedBlackTree[T, V]) deleteFixup(node *RedBlackTreeNode[T, V]) {
 		Case 4: sibling is BLACK and right child is RED
 
 		These cases are not exclusive and there can be multiple violations being fixed in the same function call
+
+		'parent' is always the parent of 'node', node can be the NIL node whose Parent pointer is not reliable
 	*/
 
 	for node != t.Root && node.NodeColor == BLACK {
 		// if fixup node is the left child of it's parent
 		switch node {
-		case node.Parent.Left:
-			sibling := node.Parent.Right
+		case parent.Left:
+			sibling := parent.Right
 			// Case 1: sibling is RED
 			if sibling.NodeColor == RED {
 				sibling.NodeColor = BLACK
-				node.Parent.NodeColor = RED
-				t.rotateLeft(node.Parent)
-				sibling = node.Parent.Right // sibling will change after rotation, set it back to node's sibling after rotation
+				parent.NodeColor = RED
+				t.rotateLeft(parent)
+				sibling = parent.Right // sibling will change after rotation, set it back to node's sibling after rotation
 			}
 
 			// Case 2: sibling is BLACK
 			if sibling.Left.NodeColor == BLACK && sibling.Right.NodeColor == BLACK {
 				sibling.NodeColor = RED
-				node = node.Parent // move current pointer from node, to node's parent
+				node = parent // move current pointer from node, to node's parent
+				parent = node.Parent
 			} else {
 				// Case 3: sibling right child is black and left is red
 				if sibling.Right.NodeColor == BLACK {
 					sibling.Left.NodeColor = BLACK
 					sibling.NodeColor = RED
 					t.rotateRight(sibling)
-					sibling = node.Parent.Right // reset sibling pointer after rotation to correct position
+					sibling = parent.Right // reset sibling pointer after rotation to correct position
 				}
 
 				// Case 4: sibling right child is RED
-				sibling.NodeColor = node.Parent.NodeColor
-				node.Parent.NodeColor = BLACK
+				sibling.NodeColor = parent.NodeColor
+				parent.NodeColor = BLACK
 				sibling.Right.NodeColor = BLACK
-				t.rotateLeft(node.Parent)
+				t.rotateLeft(parent)
 				node = t.Root
 			}
 
-		case node.Parent.Right:
+		case parent.Right:
 			// fixup node is the right child of parent
-			sibling := node.Parent.Left
+			sibling := parent.Left
 			// Case 1:
 			if sibling.NodeColor == RED {
 				sibling.NodeColor = BLACK
-				node.Parent.NodeColor = RED
-				t.rotateRight(node.Parent)
-				sibling = node.Parent.Left
+				parent.NodeColor = RED
+				t.rotateRight(parent)
+				sibling = parent.Left
 			}
 
 			// Case 2:
 			if sibling.Left.NodeColor == BLACK && sibling.Right.NodeColor == BLACK {
 				sibling.NodeColor = RED
-				node = node.Parent
+				node = parent
+				parent = node.Parent
 			} else {
 				// Case 3:
 				if sibling.Left.NodeColor == BLACK {
 					sibling.Right.NodeColor = BLACK
 					sibling.NodeColor = RED
 					t.rotateLeft(sibling)
-					sibling = node.Parent.Left
+					sibling = parent.Left
 				}
 
 				// Case 4:
-				sibling.NodeColor = node.Parent.NodeColor
-				node.Parent.NodeColor = BLACK
+				sibling.NodeColor = parent.NodeColor
+				parent.NodeColor = BLACK
 				sibling.Left.NodeColor = BLACK
-				t.rotateRight(node.Parent)
+				t.rotateRight(parent)
 				node = t.Root
 			}
 		}
 	}
 
-	node.NodeColor = BLACK
+	if node != t.NIL {
+		node.NodeColor = BLACK
+	}
 }
 
 func (t *RedBlackTree[T, V]) rotateLeft(node *RedBlackTreeNode[T, V]) {
@@ -620,6 +1128,10 @@ func (t *RedBlackTree[T, V]) rotateLeft(node *RedBlackTreeNode[T, V]) {
 	// put x on y's left
 	y.Left = x
 	x.Parent = y
+
+	// x is now y's child, so x has to be recomputed first
+	t.updateNode(x)
+	t.updateNode(y)
 }
 
 func (t *RedBlackTree[T, V]) rotateRight(node *RedBlackTreeNode[T, V]) {
@@ -659,6 +1171,9 @@ func (t *RedBlackTree[T, V]) rotateRight(node *RedBlackTreeNode[T, V]) {
 
 	y.Right = x
 	x.Parent = y
+
+	t.updateNode(x)
+	t.updateNode(y)
 }
 
 // Transplants subtree rooted at n with m
@@ -682,11 +1197,334 @@ func (t *RedBlackTree[T, V]) transplant(n, m *RedBlackTreeNode[T, V]) {
 	}
 
 	// take n's parent and give it to m
-	m.Parent = n.Parent
+	// the NIL node is never written to, callers keep track of it's parent themselves
+	if m != t.NIL {
+		m.Parent = n.Parent
+	}
+}
+
+// Splits the subtree rooted at 'node' into two valid red black trees and returns their roots and black heights.
+// 'height' is the black height of node.
+// Keys smaller than key end up on the left, the rest on the right.
+func (t *RedBlackTree[T, V]) split(node *RedBlackTreeNode[T, V], height int, key T) (*RedBlackTreeNode[T, V], int, *RedBlackTreeNode[T, V], int) {
+	if node == t.NIL {
+		return t.NIL, 0, t.NIL, 0
+	}
+
+	leftChild, rightChild := node.Left, node.Right
+	childHeight := height
+	if node.NodeColor == BLACK {
+		childHeight--
+	}
+
+	if t.compare(key, node.Key) <= 0 {
+		// node and it's right subtree belong to the right tree
+		left, leftHeight, right, rightHeight := t.split(leftChild, childHeight, key)
+		right, rightHeight = t.join(right, rightHeight, node, rightChild, childHeight)
+		return left, leftHeight, right, rightHeight
+	}
+
+	// node and it's left subtree belong to the left tree
+	left, leftHeight, right, rightHeight := t.split(rightChild, childHeight, key)
+	left, leftHeight = t.join(leftChild, childHeight, node, left, leftHeight)
+	return left, leftHeight, right, rightHeight
+}
+
+// Joins two valid red black trees using 'middle' as the separating node and returns the new root and it's black height.
+// All keys under 'left' must be smaller than middle.Key, all keys under 'right' must be greater.
+// Runs in O(|leftHeight - rightHeight| + 1) time.
+func (t *RedBlackTree[T, V]) join(left *RedBlackTreeNode[T, V], leftHeight int, middle *RedBlackTreeNode[T, V], right *RedBlackTreeNode[T, V], rightHeight int) (*RedBlackTreeNode[T, V], int) {
+	// roots can always be colored black without breaking any property,
+	// a red root turning black adds one black node to every path
+	if left != t.NIL {
+		left.Parent = t.NIL
+		if left.NodeColor == RED {
+			left.NodeColor = BLACK
+			leftHeight++
+		}
+	}
+
+	if right != t.NIL {
+		right.Parent = t.NIL
+		if right.NodeColor == RED {
+			right.NodeColor = BLACK
+			rightHeight++
+		}
+	}
+
+	middle.Parent = t.NIL
+	if leftHeight == rightHeight {
+		// both trees have the same black height, middle simply becomes the new black root
+		t.link(middle, left, right)
+		middle.NodeColor = BLACK
+		return middle, leftHeight + 1
+	}
+
+	// work on a temporary tree so rotations during fixup update it's root instead of ours
+	sub := &RedBlackTree[T, V]{NIL: t.NIL, compare: t.compare, augment: t.augment}
+	height := max(leftHeight, rightHeight)
+
+	if leftHeight > rightHeight {
+		/*
+			Walk down the right spine of the taller left tree until a black node
+			with the same black height as the right tree is found.
+			Middle takes it's place as a RED node with the found node and the right tree as children.
+			Black height of every path stays the same, only a red-red violation can appear,
+			which is exactly what insertFixup repairs.
+		*/
+		sub.Root = left
+		parent, node, nodeHeight := t.NIL, left, leftHeight
+		for node.NodeColor != BLACK || nodeHeight != rightHeight {
+			if node.NodeColor == BLACK {
+				nodeHeight--
+			}
+			parent, node = node, node.Right
+		}
+
+		t.link(middle, node, right)
+		middle.Parent = parent
+		parent.Right = middle
+	} else {
+		// mirror image of the case above, walk down the left spine of the taller right tree
+		sub.Root = right
+		parent, node, nodeHeight := t.NIL, right, rightHeight
+		for node.NodeColor != BLACK || nodeHeight != leftHeight {
+			if node.NodeColor == BLACK {
+				nodeHeight--
+			}
+			parent, node = node, node.Left
+		}
+
+		t.link(middle, left, node)
+		middle.Parent = parent
+		parent.Left = middle
+	}
+
+	middle.NodeColor = RED
+	sub.updateSubtreeSizes(middle.Parent)
+	if sub.insertFixup(middle) {
+		height++
+	}
+	return sub.Root, height
+}
+
+// Makes left and right the children of node and recomputes it's subtree size.
+func (t *RedBlackTree[T, V]) link(node, left, right *RedBlackTreeNode[T, V]) {
+	node.Left, node.Right = left, right
+	if left != t.NIL {
+		left.Parent = node
+	}
+	if right != t.NIL {
+		right.Parent = node
+	}
+	t.updateNode(node)
+}
+
+// Returns the number of black nodes on the path from 'node' down to a leaf, NIL node not included.
+func (t *RedBlackTree[T, V]) blackHeight(node *RedBlackTreeNode[T, V]) int {
+	height := 0
+	for node != t.NIL {
+		if node.NodeColor == BLACK {
+			height++
+		}
+		node = node.Left
+	}
+	return height
+}
+
+// Points every NIL child in the subtree rooted at 'node' to the given NIL node instead.
+func (t *RedBlackTree[T, V]) adoptNodes(node, nilNode *RedBlackTreeNode[T, V]) {
+	if node == t.NIL {
+		return
+	}
+
+	if node.Parent == t.NIL {
+		node.Parent = nilNode
+	}
+
+	if node.Left == t.NIL {
+		node.Left = nilNode
+	} else {
+		t.adoptNodes(node.Left, nilNode)
+	}
+
+	if node.Right == t.NIL {
+		node.Right = nilNode
+	} else {
+		t.adoptNodes(node.Right, nilNode)
+	}
+}
+
+// Builds a balanced subtree out of sorted keys and values and returns it's root.
+// Nodes at 'redDepth' are colored RED, all other nodes are colored BLACK.
+func (t *RedBlackTree[T, V]) buildSorted(keys []T, values []V, parent *RedBlackTreeNode[T, V], depth, redDepth int) *RedBlackTreeNode[T, V] {
+	if len(keys) == 0 {
+		return t.NIL
+	}
+
+	mid := len(keys) / 2
+	node := &RedBlackTreeNode[T, V]{
+		Key:       keys[mid],
+		Value:     values[mid],
+		NodeColor: BLACK,
+		Parent:    parent,
+	}
+
+	if depth == redDepth {
+		node.NodeColor = RED
+	}
+
+	node.Left = t.buildSorted(keys[:mid], values[:mid], node, depth+1, redDepth)
+	node.Right = t.buildSorted(keys[mid+1:], values[mid+1:], node, depth+1, redDepth)
+	t.updateNode(node)
+	return node
+}
+
+// Validates the subtree rooted at 'node' and returns it's node count and black height.
+// Every key in the subtree must lie strictly between the keys of 'lower' and 'upper' when they are not nil.
+func (t *RedBlackTree[T, V]) validateNode(node, lower, upper *RedBlackTreeNode[T, V]) (int, int, error) {
+	if node == t.NIL {
+		return 0, 0, nil
+	}
+
+	if node.NodeColor != RED && node.NodeColor != BLACK {
+		return 0, 0, fmt.Errorf("%w: node %v has unknown color %q", ErrInvalidTree, node.Key, node.NodeColor)
+	}
+
+	if lower != nil && t.compare(node.Key, lower.Key) <= 0 {
+		return 0, 0, fmt.Errorf("%w: key %v is not greater than ancestor key %v", ErrInvalidTree, node.Key, lower.Key)
+	}
+	if upper != nil && t.compare(node.Key, upper.Key) >= 0 {
+		return 0, 0, fmt.Errorf("%w: key %v is not smaller than ancestor key %v", ErrInvalidTree, node.Key, upper.Key)
+	}
+
+	for _, child := range []*RedBlackTreeNode[T, V]{node.Left, node.Right} {
+		if child == nil {
+			return 0, 0, fmt.Errorf("%w: node %v has a nil child, expected NIL node", ErrInvalidTree, node.Key)
+		}
+		if child == t.NIL {
+			continue
+		}
+		if child.Parent != node {
+			return 0, 0, fmt.Errorf("%w: parent pointer of key %v does not point to key %v", ErrInvalidTree, child.Key, node.Key)
+		}
+		if node.NodeColor == RED && child.NodeColor == RED {
+			return 0, 0, fmt.Errorf("%w: red node %v has red child %v", ErrInvalidTree, node.Key, child.Key)
+		}
+	}
+
+	leftCount, leftHeight, err := t.validateNode(node.Left, lower, node)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	rightCount, rightHeight, err := t.validateNode(node.Right, node, upper)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	if leftHeight != rightHeight {
+		return 0, 0, fmt.Errorf("%w: black height mismatch at key %v (left: %d, right: %d)", ErrInvalidTree, node.Key, leftHeight, rightHeight)
+	}
+
+	count := leftCount + rightCount + 1
+	if node.subtreeSize != count {
+		return 0, 0, fmt.Errorf("%w: subtree size of key %v is %d, expected %d", ErrInvalidTree, node.Key, node.subtreeSize, count)
+	}
+
+	if node.NodeColor == BLACK {
+		leftHeight++
+	}
+	return count, leftHeight, nil
+}
+
+// Writes the subtree rooted at 'node' in DOT format and returns the graph id used for it.
+// 'id' is the next unused graph id, shared across the whole traversal.
+func (t *RedBlackTree[T, V]) writeDOTNode(w io.Writer, node *RedBlackTreeNode[T, V], id *int) (string, error) {
+	name := fmt.Sprintf("n%d", *id)
+	*id++
+
+	if node == t.NIL {
+		_, err := fmt.Fprintf(w, "\t%s [shape=point, fillcolor=black];\n", name)
+		return name, err
+	}
+
+	if _, err := fmt.Fprintf(w, "\t%s [label=%q, fillcolor=%s];\n", name, fmt.Sprint(node.Key), node.NodeColor); err != nil {
+		return name, err
+	}
+
+	for _, child := range []*RedBlackTreeNode[T, V]{node.Left, node.Right} {
+		childName, err := t.writeDOTNode(w, child, id)
+		if err != nil {
+			return name, err
+		}
+
+		if _, err := fmt.Fprintf(w, "\t%s -> %s;\n", name, childName); err != nil {
+			return name, err
+		}
+	}
+
+	return name, nil
+}
+
+// Writes the subtree rooted at 'node' sideways.
+// 'prefix' is drawn right before the node label, 'abovePrefix' and 'belowPrefix'
+// are the prefixes for the right subtree drawn above and the left subtree drawn below the node.
+func (t *RedBlackTree[T, V]) writeASCIINode(w io.Writer, node *RedBlackTreeNode[T, V], prefix, abovePrefix, belowPrefix string) error {
+	if node.Right != t.NIL {
+		if err := t.writeASCIINode(w, node.Right, abovePrefix+"┌── ", abovePrefix+"    ", abovePrefix+"│   "); err != nil {
+			return err
+		}
+	}
+
+	label := "B"
+	if node.NodeColor == RED {
+		label = "R"
+	}
+
+	if _, err := fmt.Fprintf(w, "%s%v [%s]\n", prefix, node.Key, label); err != nil {
+		return err
+	}
+
+	if node.Left != t.NIL {
+		if err := t.writeASCIINode(w, node.Left, belowPrefix+"└── ", belowPrefix+"│   ", belowPrefix+"    "); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Returns which bounds of a range query are excluded.
+func parseRangeOptions(opts []RangeOption) (excludeLow, excludeHigh bool) {
+	var combined RangeOption
+	for _, opt := range opts {
+		combined |= opt
+	}
+	return combined&ExcludeLow != 0, combined&ExcludeHigh != 0
+}
+
+// Recomputes subtree sizes and augmented data from 'node' all the way up to the root.
+func (t *RedBlackTree[T, V]) updateSubtreeSizes(node *RedBlackTreeNode[T, V]) {
+	for node != t.NIL {
+		t.updateNode(node)
+		node = node.Parent
+	}
+}
+
+// Recomputes subtree size and augmented data of 'node' from it's children.
+func (t *RedBlackTree[T, V]) updateNode(node *RedBlackTreeNode[T, V]) {
+	node.subtreeSize = node.Left.subtreeSize + node.Right.subtreeSize + 1
+	if t.augment != nil {
+		t.augment(node)
+	}
 }
 
 // Returns minimum node in a subtree rooted at 'node'
 func (t *RedBlackTree[T, V]) minimum(node *RedBlackTreeNode[T, V]) *RedBlackTreeNode[T, V] {
+	if node == t.NIL {
+		return node
+	}
 	for node.Left != t.NIL {
 		node = node.Left
 	}
@@ -695,7 +1533,10 @@ func (t *RedBlackTree[T, V]) minimum(node *RedBlackTreeNode[T, V]) *RedBlackTree
 
 // Returns maximum node in a subtree rooted at 'node'
 func (t *RedBlackTree[T, V]) maximum(node *RedBlackTreeNode[T, V]) *RedBlackTreeNode[T, V] {
-	if node.Right != t.NIL {
+	if node == t.NIL {
+		return node
+	}
+	for node.Right != t.NIL {
 		node = node.Right
 	}
 	return node
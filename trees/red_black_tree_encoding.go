@@ -0,0 +1,89 @@
+package trees
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+)
+
+// The key ordering is a function and can not be serialized,
+// so trees must be created with NewRedBlackTree or NewRedBlackTreeWithFunc before decoding into them.
+var ErrNoComparator = errors.New("tree has no comparator, create it with NewRedBlackTree or NewRedBlackTreeWithFunc before decoding")
+
+// Key-value pair as it is serialized, pairs are always written in sorted order.
+type encodedPair[T any, V any] struct {
+	Key   T `json:"key"`
+	Value V `json:"value"`
+}
+
+// Implements json.Marshaler.
+// The tree is encoded as an array of {"key": ..., "value": ...} objects in sorted order.
+func (t *RedBlackTree[T, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.encodedPairs())
+}
+
+// Implements json.Unmarshaler.
+// Replaces the contents of the tree using bulk construction, the pairs must be sorted without duplicate keys.
+func (t *RedBlackTree[T, V]) UnmarshalJSON(data []byte) error {
+	var pairs []encodedPair[T, V]
+	if err := json.Unmarshal(data, &pairs); err != nil {
+		return err
+	}
+	return t.decodePairs(pairs)
+}
+
+// Implements encoding.BinaryMarshaler.
+// The key-value pairs are gob encoded in sorted order.
+func (t *RedBlackTree[T, V]) MarshalBinary() ([]byte, error) {
+	var buffer bytes.Buffer
+	if err := gob.NewEncoder(&buffer).Encode(t.encodedPairs()); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// Implements encoding.BinaryUnmarshaler.
+// Replaces the contents of the tree using bulk construction, the pairs must be sorted without duplicate keys.
+func (t *RedBlackTree[T, V]) UnmarshalBinary(data []byte) error {
+	var pairs []encodedPair[T, V]
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&pairs); err != nil {
+		return err
+	}
+	return t.decodePairs(pairs)
+}
+
+// Implements gob.GobEncoder, same encoding as MarshalBinary.
+func (t *RedBlackTree[T, V]) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// Implements gob.GobDecoder, same encoding as UnmarshalBinary.
+func (t *RedBlackTree[T, V]) GobDecode(data []byte) error {
+	return t.UnmarshalBinary(data)
+}
+
+// Returns all key-value pairs of the tree in sorted order.
+func (t *RedBlackTree[T, V]) encodedPairs() []encodedPair[T, V] {
+	pairs := make([]encodedPair[T, V], 0, t.treeSize)
+	for key, value := range t.ForwardIterator() {
+		pairs = append(pairs, encodedPair[T, V]{Key: key, Value: value})
+	}
+	return pairs
+}
+
+// Replaces the contents of the tree with the decoded pairs.
+func (t *RedBlackTree[T, V]) decodePairs(pairs []encodedPair[T, V]) error {
+	if t.compare == nil || t.NIL == nil {
+		return ErrNoComparator
+	}
+
+	keys := make([]T, len(pairs))
+	values := make([]V, len(pairs))
+	for i, pair := range pairs {
+		keys[i] = pair.Key
+		values[i] = pair.Value
+	}
+
+	return t.FromSorted(keys, values)
+}
@@ -0,0 +1,182 @@
+package trees
+
+import (
+	"bytes"
+	"cmp"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"slices"
+	"testing"
+)
+
+var (
+	_ json.Marshaler             = (*RedBlackTree[int, int])(nil)
+	_ json.Unmarshaler           = (*RedBlackTree[int, int])(nil)
+	_ encoding.BinaryMarshaler   = (*RedBlackTree[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*RedBlackTree[int, int])(nil)
+	_ gob.GobEncoder             = (*RedBlackTree[int, int])(nil)
+	_ gob.GobDecoder             = (*RedBlackTree[int, int])(nil)
+)
+
+type encodingPoint struct {
+	X int
+	Y int
+}
+
+func comparePoints(a, b encodingPoint) int {
+	if result := cmp.Compare(a.X, b.X); result != 0 {
+		return result
+	}
+	return cmp.Compare(a.Y, b.Y)
+}
+
+// Encodes source with every supported encoding, decodes into a fresh tree made by newTree and compares the contents.
+func testRoundTrip[T comparable, V comparable](t *testing.T, source *RedBlackTree[T, V], newTree func() *RedBlackTree[T, V]) {
+	encodings := []struct {
+		name   string
+		encode func() ([]byte, error)
+		decode func(tree *RedBlackTree[T, V], data []byte) error
+	}{
+		{"json", func() ([]byte, error) { return json.Marshal(source) }, func(tree *RedBlackTree[T, V], data []byte) error { return json.Unmarshal(data, tree) }},
+		{"binary", source.MarshalBinary, func(tree *RedBlackTree[T, V], data []byte) error { return tree.UnmarshalBinary(data) }},
+		{"gob", func() ([]byte, error) {
+			var buffer bytes.Buffer
+			err := gob.NewEncoder(&buffer).Encode(source)
+			return buffer.Bytes(), err
+		}, func(tree *RedBlackTree[T, V], data []byte) error {
+			return gob.NewDecoder(bytes.NewReader(data)).Decode(tree)
+		}},
+	}
+
+	for _, enc := range encodings {
+		t.Run(enc.name, func(t *testing.T) {
+			data, err := enc.encode()
+			if err != nil {
+				t.Fatalf("Unexpected encoding error: %v", err)
+			}
+
+			decoded := newTree()
+			if err := enc.decode(decoded, data); err != nil {
+				t.Fatalf("Unexpected decoding error: %v", err)
+			}
+			verifyRedBlackProperties(t, decoded)
+
+			if decoded.Size() != source.Size() {
+				t.Errorf("Expected size %d, got %d", source.Size(), decoded.Size())
+			}
+
+			expected := make([]T, 0)
+			for key := range source.ForwardIterator() {
+				expected = append(expected, key)
+			}
+
+			actual := make([]T, 0)
+			for key := range decoded.ForwardIterator() {
+				actual = append(actual, key)
+			}
+
+			if !slices.Equal(expected, actual) {
+				t.Errorf("Expected keys %v, got %v", expected, actual)
+			}
+
+			for key, value := range source.ForwardIterator() {
+				if node, found := decoded.Search(key); !found || node.Value != value {
+					t.Errorf("Expected value %v for key %v", value, key)
+				}
+			}
+		})
+	}
+}
+
+func TestRedBlackTreeEncoding(t *testing.T) {
+	t.Run("int keys", func(t *testing.T) {
+		source := NewRedBlackTree[int, string]()
+		for i := 0; i < 100; i++ {
+			source.Insert((i*31)%100, "value")
+		}
+		testRoundTrip(t, source, NewRedBlackTree[int, string])
+	})
+
+	t.Run("string keys", func(t *testing.T) {
+		source := NewRedBlackTree[string, int]()
+		for i, key := range []string{"pear", "apple", "fig", "kiwi", "banana"} {
+			source.Insert(key, i)
+		}
+		testRoundTrip(t, source, NewRedBlackTree[string, int])
+	})
+
+	t.Run("float keys", func(t *testing.T) {
+		source := NewRedBlackTree[float64, bool]()
+		for _, key := range []float64{3.5, -1.25, 0, 100.125} {
+			source.Insert(key, key > 0)
+		}
+		testRoundTrip(t, source, NewRedBlackTree[float64, bool])
+	})
+
+	t.Run("struct keys", func(t *testing.T) {
+		newTree := func() *RedBlackTree[encodingPoint, string] {
+			return NewRedBlackTreeWithFunc[encodingPoint, string](comparePoints)
+		}
+
+		source := newTree()
+		source.Insert(encodingPoint{2, 1}, "c")
+		source.Insert(encodingPoint{1, 5}, "b")
+		source.Insert(encodingPoint{1, 2}, "a")
+		testRoundTrip(t, source, newTree)
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		testRoundTrip(t, NewRedBlackTree[int, int](), NewRedBlackTree[int, int])
+	})
+}
+
+func TestRedBlackTreeDecodingErrors(t *testing.T) {
+	t.Run("json format", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		tree.Insert(1, "one")
+		tree.Insert(2, "two")
+
+		data, err := json.Marshal(tree)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := `[{"key":1,"value":"one"},{"key":2,"value":"two"}]`
+		if string(data) != expected {
+			t.Errorf("Expected %s, got %s", expected, data)
+		}
+	})
+
+	t.Run("unsorted input", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		err := json.Unmarshal([]byte(`[{"key":2,"value":"two"},{"key":1,"value":"one"}]`), tree)
+		if !errors.Is(err, ErrUnsortedInput) {
+			t.Errorf("Expected ErrUnsortedInput, got %v", err)
+		}
+	})
+
+	t.Run("duplicate input", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		err := json.Unmarshal([]byte(`[{"key":1,"value":"a"},{"key":1,"value":"b"}]`), tree)
+		if !errors.Is(err, ErrDuplicateKey) {
+			t.Errorf("Expected ErrDuplicateKey, got %v", err)
+		}
+	})
+
+	t.Run("zero value tree", func(t *testing.T) {
+		var tree RedBlackTree[int, string]
+		err := json.Unmarshal([]byte(`[{"key":1,"value":"one"}]`), &tree)
+		if !errors.Is(err, ErrNoComparator) {
+			t.Errorf("Expected ErrNoComparator, got %v", err)
+		}
+	})
+
+	t.Run("corrupt binary", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		if err := tree.UnmarshalBinary([]byte("not gob")); err == nil {
+			t.Error("Expected error for corrupt binary input")
+		}
+	})
+}
@@ -2,11 +2,16 @@ package trees
 
 import (
 	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
 	"testing"
 )
 
 // Helper function to verify red-black tree properties
-func verifyRedBlackProperties[T cmp.Ordered, V any](t *testing.T, tree *RedBlackTree[T, V]) {
+func verifyRedBlackProperties[T any, V any](t *testing.T, tree *RedBlackTree[T, V]) {
 	// Property 1: Root must be black
 	if tree.Root != tree.NIL && tree.Root.NodeColor != BLACK {
 		t.Error("Property violation: Root must be black")
@@ -14,9 +19,32 @@ func verifyRedBlackProperties[T cmp.Ordered, V any](t *testing.T, tree *RedBlack
 
 	// Verify other properties using helper
 	verifyNode(t, tree, tree.Root)
+
+	// Verify order statistic augmentation
+	if size := verifySubtreeSize(t, tree, tree.Root); size != tree.Size() {
+		t.Errorf("Subtree size of root %d does not match tree size %d", size, tree.Size())
+	}
+	if err := tree.Validate(); err != nil {
+		t.Error(err)
+	}
 }
 
-func verifyNode[T cmp.Ordered, V any](t *testing.T, tree *RedBlackTree[T, V], node *RedBlackTreeNode[T, V]) int {
+func verifySubtreeSize[T any, V any](t *testing.T, tree *RedBlackTree[T, V], node *RedBlackTreeNode[T, V]) int {
+	if node == tree.NIL {
+		if node.subtreeSize != 0 {
+			t.Errorf("NIL node should have subtree size 0, got %d", node.subtreeSize)
+		}
+		return 0
+	}
+
+	size := verifySubtreeSize(t, tree, node.Left) + verifySubtreeSize(t, tree, node.Right) + 1
+	if node.subtreeSize != size {
+		t.Errorf("Subtree size mismatch at node %v (stored: %d, actual: %d)", node.Key, node.subtreeSize, size)
+	}
+	return size
+}
+
+func verifyNode[T any, V any](t *testing.T, tree *RedBlackTree[T, V], node *RedBlackTreeNode[T, V]) int {
 	if node == tree.NIL {
 		return 1 // NIL nodes are black and count as 1 black node
 	}
@@ -500,3 +528,1188 @@ func TestComplexInsertDeleteSequence(t *testing.T) {
 		t.Errorf("Expected size %d, got %d", expectedSize, tree.Size())
 	}
 }
+
+func TestNewRedBlackTreeWithFunc(t *testing.T) {
+	t.Run("test comparator with composite struct keys", func(t *testing.T) {
+		type tenantKey struct {
+			tenant    string
+			timestamp int
+		}
+
+		comparator := func(a, b tenantKey) int {
+			if result := cmp.Compare(a.tenant, b.tenant); result != 0 {
+				return result
+			}
+			return cmp.Compare(a.timestamp, b.timestamp)
+		}
+
+		tree := NewRedBlackTreeWithFunc[tenantKey, int](comparator)
+		keys := []tenantKey{
+			{"beta", 2},
+			{"alpha", 3},
+			{"beta", 1},
+			{"alpha", 1},
+			{"gamma", 0},
+			{"alpha", 2},
+		}
+
+		for i, key := range keys {
+			tree.Insert(key, i)
+			verifyRedBlackProperties(t, tree)
+		}
+
+		if tree.Size() != len(keys) {
+			t.Errorf("Expected size %d, got %d", len(keys), tree.Size())
+		}
+
+		expected := []tenantKey{
+			{"alpha", 1},
+			{"alpha", 2},
+			{"alpha", 3},
+			{"beta", 1},
+			{"beta", 2},
+			{"gamma", 0},
+		}
+
+		index := 0
+		for key := range tree.ForwardIterator() {
+			if key != expected[index] {
+				t.Errorf("Expected key %v at index %d, got %v", expected[index], index, key)
+			}
+			index++
+		}
+
+		node, found := tree.Search(tenantKey{"alpha", 3})
+		if !found || node.Value != 1 {
+			t.Error("Failed to find key {alpha 3}")
+		}
+
+		if !tree.Delete(tenantKey{"beta", 1}) {
+			t.Error("Failed to delete key {beta 1}")
+		}
+		verifyRedBlackProperties(t, tree)
+
+		if _, found := tree.Search(tenantKey{"beta", 1}); found {
+			t.Error("Key {beta 1} should not be found after deletion")
+		}
+	})
+
+	t.Run("test comparator with case insensitive string keys", func(t *testing.T) {
+		tree := NewRedBlackTreeWithFunc[string, int](func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+
+		tree.Insert("Apple", 1)
+		tree.Insert("banana", 2)
+		tree.Insert("APPLE", 3)
+
+		if tree.Size() != 2 {
+			t.Errorf("Expected size 2, got %d", tree.Size())
+		}
+
+		node, found := tree.Search("apple")
+		if !found || node.Value != 3 {
+			t.Error("Expected case insensitive search to find updated value 3")
+		}
+
+		if !tree.Delete("BANANA") {
+			t.Error("Failed to delete key 'BANANA'")
+		}
+
+		verifyRedBlackProperties(t, tree)
+	})
+}
+
+func TestClear(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	tree.Clear()
+
+	if !tree.IsEmpty() {
+		t.Error("Tree should be empty after Clear")
+	}
+
+	if tree.Size() != 0 {
+		t.Errorf("Expected size 0 after Clear, got %d", tree.Size())
+	}
+}
+
+func TestOrderStatistics(t *testing.T) {
+	t.Run("test select", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 100; i++ {
+			tree.Insert((i*37)%100, i) // insert 0..99 in scrambled order
+		}
+		verifyRedBlackProperties(t, tree)
+
+		for k := 0; k < 100; k++ {
+			node, found := tree.Select(k)
+			if !found || node.Key != k {
+				t.Errorf("Expected key %d for Select(%d), got %v", k, k, node.Key)
+			}
+		}
+
+		if _, found := tree.Select(-1); found {
+			t.Error("Select(-1) should not find a node")
+		}
+
+		if _, found := tree.Select(100); found {
+			t.Error("Select(100) should not find a node")
+		}
+	})
+
+	t.Run("test rank", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		for _, key := range []int{10, 20, 30, 40, 50} {
+			tree.Insert(key, "value")
+		}
+
+		testCases := []struct {
+			key  int
+			rank int
+		}{
+			{5, 0},
+			{10, 0},
+			{15, 1},
+			{30, 2},
+			{50, 4},
+			{55, 5},
+		}
+
+		for _, tc := range testCases {
+			if rank := tree.Rank(tc.key); rank != tc.rank {
+				t.Errorf("Expected Rank(%d) = %d, got %d", tc.key, tc.rank, rank)
+			}
+		}
+	})
+
+	t.Run("test count range", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		for _, key := range []int{10, 20, 30, 40, 50} {
+			tree.Insert(key, "value")
+		}
+
+		testCases := []struct {
+			lo, hi int
+			count  int
+		}{
+			{10, 50, 5},
+			{15, 45, 3},
+			{20, 20, 1},
+			{21, 29, 0},
+			{0, 100, 5},
+			{50, 10, 0},
+		}
+
+		for _, tc := range testCases {
+			if count := tree.CountRange(tc.lo, tc.hi); count != tc.count {
+				t.Errorf("Expected CountRange(%d, %d) = %d, got %d", tc.lo, tc.hi, tc.count, count)
+			}
+		}
+	})
+
+	t.Run("test order statistics after deletions", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 200; i++ {
+			tree.Insert(i, i)
+		}
+
+		for i := 0; i < 200; i += 3 {
+			tree.Delete(i)
+			verifyRedBlackProperties(t, tree)
+		}
+
+		remaining := make([]int, 0)
+		for i := 0; i < 200; i++ {
+			if i%3 != 0 {
+				remaining = append(remaining, i)
+			}
+		}
+
+		for k, key := range remaining {
+			node, found := tree.Select(k)
+			if !found || node.Key != key {
+				t.Errorf("Expected key %d for Select(%d), got %v", key, k, node.Key)
+			}
+
+			if rank := tree.Rank(key); rank != k {
+				t.Errorf("Expected Rank(%d) = %d, got %d", key, k, rank)
+			}
+		}
+	})
+}
+
+func TestBeginEnd(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for _, key := range []int{50, 20, 80, 10, 30, 70, 90, 60} {
+		tree.Insert(key, key*10)
+	}
+
+	keys := make([]int, 0)
+	for it := tree.Begin(); it.Valid(); it.Next() {
+		key, _ := it.Val()
+		keys = append(keys, key)
+	}
+
+	expected := []int{10, 20, 30, 50, 60, 70, 80, 90}
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Expected forward keys %v, got %v", expected, keys)
+	}
+
+	keys = keys[:0]
+	for it := tree.End(); it.Valid(); it.Prev() {
+		key, _ := it.Val()
+		keys = append(keys, key)
+	}
+
+	slices.Reverse(expected)
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Expected backward keys %v, got %v", expected, keys)
+	}
+}
+
+func TestNavigation(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+	for _, key := range []int{10, 20, 30, 40, 50} {
+		tree.Insert(key, fmt.Sprintf("value-%d", key))
+	}
+
+	testCases := []struct {
+		name     string
+		navigate func(int) (*Cursor[int, string], bool)
+		key      int
+		expected int
+		found    bool
+	}{
+		{"floor exact", tree.Floor, 30, 30, true},
+		{"floor between", tree.Floor, 35, 30, true},
+		{"floor below min", tree.Floor, 5, 0, false},
+		{"floor above max", tree.Floor, 100, 50, true},
+		{"ceiling exact", tree.Ceiling, 30, 30, true},
+		{"ceiling between", tree.Ceiling, 35, 40, true},
+		{"ceiling below min", tree.Ceiling, 5, 10, true},
+		{"ceiling above max", tree.Ceiling, 100, 0, false},
+		{"lower exact", tree.Lower, 30, 20, true},
+		{"lower between", tree.Lower, 35, 30, true},
+		{"lower at min", tree.Lower, 10, 0, false},
+		{"higher exact", tree.Higher, 30, 40, true},
+		{"higher between", tree.Higher, 35, 40, true},
+		{"higher at max", tree.Higher, 50, 0, false},
+		{"lower bound", tree.LowerBound, 20, 20, true},
+		{"upper bound", tree.UpperBound, 20, 30, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			it, found := tc.navigate(tc.key)
+			if found != tc.found {
+				t.Fatalf("Expected found = %v, got %v", tc.found, found)
+			}
+
+			if !found {
+				return
+			}
+
+			key, value := it.Val()
+			if key != tc.expected || value != fmt.Sprintf("value-%d", tc.expected) {
+				t.Errorf("Expected key %d, got %d (%s)", tc.expected, key, value)
+			}
+		})
+	}
+
+	t.Run("iterator continues from found node", func(t *testing.T) {
+		it, _ := tree.Ceiling(25)
+		it.Next()
+		if key, _ := it.Val(); key != 40 {
+			t.Errorf("Expected key 40 after Next, got %d", key)
+		}
+	})
+}
+
+func TestMinMax(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+
+	if _, found := tree.Min(); found {
+		t.Error("Min should not find a key in an empty tree")
+	}
+
+	if _, found := tree.Max(); found {
+		t.Error("Max should not find a key in an empty tree")
+	}
+
+	if _, _, found := tree.PopMin(); found {
+		t.Error("PopMin should not find a key in an empty tree")
+	}
+
+	if _, _, found := tree.PopMax(); found {
+		t.Error("PopMax should not find a key in an empty tree")
+	}
+
+	for _, key := range []int{5, 3, 8, 1, 4, 9, 7} {
+		tree.Insert(key, fmt.Sprintf("value-%d", key))
+	}
+
+	it, found := tree.Min()
+	if key, _ := it.Val(); !found || key != 1 {
+		t.Errorf("Expected min key 1, got %d", key)
+	}
+
+	it, found = tree.Max()
+	if key, _ := it.Val(); !found || key != 9 {
+		t.Errorf("Expected max key 9, got %d", key)
+	}
+
+	key, value, found := tree.PopMin()
+	if !found || key != 1 || value != "value-1" {
+		t.Errorf("Expected PopMin to return 1, got %d (%s)", key, value)
+	}
+	verifyRedBlackProperties(t, tree)
+
+	key, value, found = tree.PopMax()
+	if !found || key != 9 || value != "value-9" {
+		t.Errorf("Expected PopMax to return 9, got %d (%s)", key, value)
+	}
+	verifyRedBlackProperties(t, tree)
+
+	if tree.Size() != 5 {
+		t.Errorf("Expected size 5, got %d", tree.Size())
+	}
+
+	popped := make([]int, 0)
+	for !tree.IsEmpty() {
+		key, _, _ := tree.PopMin()
+		popped = append(popped, key)
+		verifyRedBlackProperties(t, tree)
+	}
+
+	expected := []int{3, 4, 5, 7, 8}
+	if !slices.Equal(popped, expected) {
+		t.Errorf("Expected popped keys %v, got %v", expected, popped)
+	}
+}
+
+func TestRange(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 0; i < 100; i += 10 {
+		tree.Insert(i, i*2)
+	}
+
+	collect := func(seq iter.Seq2[int, int]) []int {
+		keys := make([]int, 0)
+		for key, value := range seq {
+			if value != key*2 {
+				t.Errorf("Expected value %d for key %d, got %d", key*2, key, value)
+			}
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	testCases := []struct {
+		name     string
+		seq      iter.Seq2[int, int]
+		expected []int
+	}{
+		{"inclusive", tree.Range(20, 50), []int{20, 30, 40, 50}},
+		{"bounds between keys", tree.Range(15, 55), []int{20, 30, 40, 50}},
+		{"exclude low", tree.Range(20, 50, ExcludeLow), []int{30, 40, 50}},
+		{"exclude high", tree.Range(20, 50, ExcludeHigh), []int{20, 30, 40}},
+		{"exclude both", tree.Range(20, 50, ExcludeLow, ExcludeHigh), []int{30, 40}},
+		{"exclude both combined", tree.Range(20, 50, ExcludeLow|ExcludeHigh), []int{30, 40}},
+		{"single key", tree.Range(30, 30), []int{30}},
+		{"empty interval", tree.Range(31, 39), []int{}},
+		{"inverted interval", tree.Range(50, 20), []int{}},
+		{"whole tree", tree.Range(-100, 100), []int{0, 10, 20, 30, 40, 50, 60, 70, 80, 90}},
+		{"descending inclusive", tree.RangeDesc(50, 20), []int{50, 40, 30, 20}},
+		{"descending bounds between keys", tree.RangeDesc(55, 15), []int{50, 40, 30, 20}},
+		{"descending exclude low", tree.RangeDesc(50, 20, ExcludeLow), []int{50, 40, 30}},
+		{"descending exclude high", tree.RangeDesc(50, 20, ExcludeHigh), []int{40, 30, 20}},
+		{"descending inverted interval", tree.RangeDesc(20, 50), []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if keys := collect(tc.seq); !slices.Equal(keys, tc.expected) {
+				t.Errorf("Expected keys %v, got %v", tc.expected, keys)
+			}
+		})
+	}
+
+	t.Run("early termination", func(t *testing.T) {
+		keys := make([]int, 0)
+		for key := range tree.Range(0, 90) {
+			if key > 30 {
+				break
+			}
+			keys = append(keys, key)
+		}
+
+		if !slices.Equal(keys, []int{0, 10, 20, 30}) {
+			t.Errorf("Expected keys [0 10 20 30], got %v", keys)
+		}
+	})
+
+	t.Run("empty tree", func(t *testing.T) {
+		empty := NewRedBlackTree[int, int]()
+		if keys := collect(empty.Range(0, 100)); len(keys) != 0 {
+			t.Errorf("Expected no keys, got %v", keys)
+		}
+	})
+}
+
+func TestIteratorDoesNotModifyTree(t *testing.T) {
+	tree := NewRedBlackTree[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	// breaking out of the loop early must leave the tree intact
+	for key := range tree.ForwardIterator() {
+		if key == 42 {
+			break
+		}
+	}
+
+	for key := range tree.BackwardIterator() {
+		if key == 42 {
+			break
+		}
+	}
+
+	verifyRedBlackProperties(t, tree)
+
+	count := 0
+	for range tree.ForwardIterator() {
+		count++
+	}
+
+	if count != 100 {
+		t.Errorf("Expected 100 iterations, got %d", count)
+	}
+}
+
+const benchmarkTreeSize = 1_000_000
+
+var benchmarkTree *RedBlackTree[int, int]
+
+func getBenchmarkTree() *RedBlackTree[int, int] {
+	if benchmarkTree == nil {
+		benchmarkTree = NewRedBlackTree[int, int]()
+		for i := 0; i < benchmarkTreeSize; i++ {
+			benchmarkTree.Insert(i, i)
+		}
+	}
+	return benchmarkTree
+}
+
+// Materializes the whole tree using Morris traversal before yielding anything.
+// This is how the iterators used to work, kept here as a baseline for the benchmarks.
+func morrisIterator[T any, V any](t *RedBlackTree[T, V]) iter.Seq2[T, V] {
+	type Pair struct {
+		key   T
+		value V
+	}
+
+	result := make([]Pair, 0, t.treeSize)
+
+	currentNode := t.Root
+	for currentNode != t.NIL {
+		if currentNode.Left == t.NIL {
+			result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
+			currentNode = currentNode.Right
+		} else {
+			inOrderPredecessor := currentNode.Left
+			for inOrderPredecessor.Right != t.NIL && inOrderPredecessor.Right != currentNode {
+				inOrderPredecessor = inOrderPredecessor.Right
+			}
+
+			switch inOrderPredecessor.Right {
+			case t.NIL:
+				inOrderPredecessor.Right = currentNode
+				currentNode = currentNode.Left
+			case currentNode:
+				inOrderPredecessor.Right = t.NIL
+				result = append(result, Pair{key: currentNode.Key, value: currentNode.Value})
+				currentNode = currentNode.Right
+			}
+		}
+	}
+
+	return func(yield func(T, V) bool) {
+		for _, pair := range result {
+			if !yield(pair.key, pair.value) {
+				return
+			}
+		}
+	}
+}
+
+func BenchmarkForwardIteratorFull(b *testing.B) {
+	tree := getBenchmarkTree()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		for range tree.ForwardIterator() {
+		}
+	}
+}
+
+func BenchmarkMorrisIteratorFull(b *testing.B) {
+	tree := getBenchmarkTree()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		for range morrisIterator(tree) {
+		}
+	}
+}
+
+func BenchmarkForwardIteratorFirstTen(b *testing.B) {
+	tree := getBenchmarkTree()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		count := 0
+		for range tree.ForwardIterator() {
+			count++
+			if count == 10 {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkBackwardIteratorFirstTen(b *testing.B) {
+	tree := getBenchmarkTree()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		count := 0
+		for range tree.BackwardIterator() {
+			count++
+			if count == 10 {
+				break
+			}
+		}
+	}
+}
+
+func BenchmarkMorrisIteratorFirstTen(b *testing.B) {
+	tree := getBenchmarkTree()
+	b.ReportAllocs()
+
+	for b.Loop() {
+		count := 0
+		for range morrisIterator(tree) {
+			count++
+			if count == 10 {
+				break
+			}
+		}
+	}
+}
+
+func TestCursor(t *testing.T) {
+	t.Run("test invalid cursor", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		cursor := tree.Begin()
+
+		if cursor.Valid() {
+			t.Error("Cursor on empty tree should not be valid")
+		}
+
+		if key, value := cursor.Val(); key != 0 || value != "" {
+			t.Errorf("Invalid cursor should return zero values, got %d (%s)", key, value)
+		}
+
+		if cursor.Next() || cursor.Prev() {
+			t.Error("Invalid cursor should not move")
+		}
+
+		if cursor.SetValue("value") {
+			t.Error("SetValue on invalid cursor should return false")
+		}
+
+		if cursor.Erase() {
+			t.Error("Erase on invalid cursor should return false")
+		}
+	})
+
+	t.Run("test next and prev", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		tree.Insert(1, "one")
+		tree.Insert(2, "two")
+
+		cursor := tree.Begin()
+		if !cursor.Valid() || cursor.Key() != 1 || cursor.Value() != "one" {
+			t.Errorf("Expected cursor at 1, got %d", cursor.Key())
+		}
+
+		if !cursor.Next() || cursor.Key() != 2 {
+			t.Errorf("Expected cursor at 2, got %d", cursor.Key())
+		}
+
+		if cursor.Next() {
+			t.Error("Next past the last key should return false")
+		}
+
+		if cursor.Valid() {
+			t.Error("Cursor past the last key should not be valid")
+		}
+
+		cursor = tree.End()
+		if !cursor.Prev() || cursor.Key() != 1 {
+			t.Errorf("Expected cursor at 1, got %d", cursor.Key())
+		}
+
+		if cursor.Prev() {
+			t.Error("Prev past the first key should return false")
+		}
+	})
+
+	t.Run("test seek", func(t *testing.T) {
+		tree := NewRedBlackTree[int, string]()
+		for _, key := range []int{10, 20, 30} {
+			tree.Insert(key, "value")
+		}
+
+		cursor := tree.Begin()
+		if !cursor.Seek(20) || cursor.Key() != 20 {
+			t.Errorf("Expected cursor at 20, got %d", cursor.Key())
+		}
+
+		if !cursor.Seek(21) || cursor.Key() != 30 {
+			t.Errorf("Expected cursor at 30, got %d", cursor.Key())
+		}
+
+		if cursor.Seek(31) {
+			t.Error("Seek past the last key should return false")
+		}
+
+		// seeking revives an invalid cursor
+		if !cursor.Seek(0) || cursor.Key() != 10 {
+			t.Errorf("Expected cursor at 10, got %d", cursor.Key())
+		}
+	})
+
+	t.Run("test set value", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 10; i++ {
+			tree.Insert(i, i)
+		}
+
+		for cursor := tree.Begin(); cursor.Valid(); cursor.Next() {
+			cursor.SetValue(cursor.Value() * 100)
+		}
+
+		for key, value := range tree.ForwardIterator() {
+			if value != key*100 {
+				t.Errorf("Expected value %d for key %d, got %d", key*100, key, value)
+			}
+		}
+	})
+
+	t.Run("test filtered erase", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 500; i++ {
+			tree.Insert(i, i)
+		}
+
+		// erase every multiple of 3 in a single pass
+		cursor := tree.Begin()
+		for cursor.Valid() {
+			if cursor.Key()%3 == 0 {
+				cursor.Erase()
+			} else {
+				cursor.Next()
+			}
+		}
+		verifyRedBlackProperties(t, tree)
+
+		expected := make([]int, 0)
+		for i := 0; i < 500; i++ {
+			if i%3 != 0 {
+				expected = append(expected, i)
+			}
+		}
+
+		keys := make([]int, 0)
+		for key := range tree.ForwardIterator() {
+			keys = append(keys, key)
+		}
+
+		if !slices.Equal(keys, expected) {
+			t.Errorf("Expected %d keys after filtered erase, got %d", len(expected), len(keys))
+		}
+
+		if tree.Size() != len(expected) {
+			t.Errorf("Expected size %d, got %d", len(expected), tree.Size())
+		}
+	})
+
+	t.Run("test erase everything", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 50; i++ {
+			tree.Insert(i, i)
+		}
+
+		cursor := tree.Begin()
+		for cursor.Erase() {
+			verifyRedBlackProperties(t, tree)
+		}
+
+		if !tree.IsEmpty() || tree.Size() != 0 {
+			t.Errorf("Expected empty tree, got size %d", tree.Size())
+		}
+	})
+}
+
+func TestFromSorted(t *testing.T) {
+	t.Run("test every size builds a valid tree", func(t *testing.T) {
+		for n := 0; n <= 300; n++ {
+			keys := make([]int, n)
+			values := make([]string, n)
+			for i := range n {
+				keys[i] = i * 2
+				values[i] = fmt.Sprintf("value-%d", i*2)
+			}
+
+			tree := NewRedBlackTree[int, string]()
+			if err := tree.FromSorted(keys, values); err != nil {
+				t.Fatalf("Unexpected error for size %d: %v", n, err)
+			}
+
+			verifyRedBlackProperties(t, tree)
+
+			if tree.Size() != n {
+				t.Errorf("Expected size %d, got %d", n, tree.Size())
+			}
+
+			index := 0
+			for key, value := range tree.ForwardIterator() {
+				if key != keys[index] || value != values[index] {
+					t.Errorf("Expected %d (%s) at index %d, got %d (%s)", keys[index], values[index], index, key, value)
+				}
+				index++
+			}
+		}
+	})
+
+	t.Run("test tree remains usable after bulk construction", func(t *testing.T) {
+		keys := make([]int, 100)
+		for i := range keys {
+			keys[i] = i * 10
+		}
+
+		tree := NewRedBlackTree[int, int]()
+		if err := tree.FromSorted(keys, keys); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		for i := 0; i < 1000; i += 3 {
+			tree.Insert(i, i)
+			verifyRedBlackProperties(t, tree)
+		}
+
+		for i := 0; i < 1000; i += 7 {
+			tree.Delete(i)
+			verifyRedBlackProperties(t, tree)
+		}
+	})
+
+	t.Run("test invalid input", func(t *testing.T) {
+		testCases := []struct {
+			name   string
+			keys   []int
+			values []int
+			err    error
+		}{
+			{"unsorted", []int{1, 3, 2}, []int{1, 3, 2}, ErrUnsortedInput},
+			{"duplicate", []int{1, 2, 2}, []int{1, 2, 2}, ErrDuplicateKey},
+			{"length mismatch", []int{1, 2}, []int{1}, ErrLengthMismatch},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				tree := NewRedBlackTree[int, int]()
+				tree.Insert(100, 100)
+
+				if err := tree.FromSorted(tc.keys, tc.values); !errors.Is(err, tc.err) {
+					t.Errorf("Expected error %v, got %v", tc.err, err)
+				}
+
+				if tree.Size() != 1 {
+					t.Error("Tree should be unchanged after failed bulk construction")
+				}
+			})
+		}
+	})
+
+	t.Run("test replaces existing contents", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		tree.Insert(100, 100)
+
+		if err := tree.FromSorted([]int{1, 2, 3}, []int{1, 2, 3}); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if _, found := tree.Search(100); found || tree.Size() != 3 {
+			t.Error("Bulk construction should replace existing contents")
+		}
+	})
+}
+
+func TestFromSeq(t *testing.T) {
+	source := NewRedBlackTree[string, int]()
+	for i, key := range []string{"delta", "alpha", "charlie", "bravo"} {
+		source.Insert(key, i)
+	}
+
+	tree := NewRedBlackTree[string, int]()
+	if err := tree.FromSeq(source.ForwardIterator()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	verifyRedBlackProperties(t, tree)
+
+	expected := []string{"alpha", "bravo", "charlie", "delta"}
+	keys := make([]string, 0)
+	for key, value := range tree.ForwardIterator() {
+		if node, _ := source.Search(key); node.Value != value {
+			t.Errorf("Expected value %d for key %s, got %d", node.Value, key, value)
+		}
+		keys = append(keys, key)
+	}
+
+	if !slices.Equal(keys, expected) {
+		t.Errorf("Expected keys %v, got %v", expected, keys)
+	}
+
+	if err := tree.FromSeq(source.BackwardIterator()); !errors.Is(err, ErrUnsortedInput) {
+		t.Errorf("Expected ErrUnsortedInput for descending sequence, got %v", err)
+	}
+}
+
+func BenchmarkInsertSorted(b *testing.B) {
+	for b.Loop() {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < benchmarkTreeSize; i++ {
+			tree.Insert(i, i)
+		}
+	}
+}
+
+func BenchmarkFromSorted(b *testing.B) {
+	keys := make([]int, benchmarkTreeSize)
+	for i := range keys {
+		keys[i] = i
+	}
+
+	for b.Loop() {
+		tree := NewRedBlackTree[int, int]()
+		tree.FromSorted(keys, keys)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	keysOf := func(tree *RedBlackTree[int, int]) []int {
+		keys := make([]int, 0)
+		for key, value := range tree.ForwardIterator() {
+			if value != key*10 {
+				t.Errorf("Expected value %d for key %d, got %d", key*10, key, value)
+			}
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	for _, n := range []int{0, 1, 2, 3, 10, 100, 257} {
+		for _, splitKey := range []int{-1, 0, 1, n / 3, n / 2, n - 1, n, n + 5} {
+			t.Run(fmt.Sprintf("size %d at key %d", n, splitKey), func(t *testing.T) {
+				tree := NewRedBlackTree[int, int]()
+				for i := 0; i < n; i++ {
+					tree.Insert((i*7)%n, (i*7)%n*10) // scrambled insertion order
+				}
+
+				left, right := tree.Split(splitKey)
+				verifyRedBlackProperties(t, left)
+				verifyRedBlackProperties(t, right)
+
+				expectedLeft := make([]int, 0)
+				expectedRight := make([]int, 0)
+				for i := 0; i < n; i++ {
+					if i < splitKey {
+						expectedLeft = append(expectedLeft, i)
+					} else {
+						expectedRight = append(expectedRight, i)
+					}
+				}
+
+				if keys := keysOf(left); !slices.Equal(keys, expectedLeft) {
+					t.Errorf("Expected left keys %v, got %v", expectedLeft, keys)
+				}
+
+				if keys := keysOf(right); !slices.Equal(keys, expectedRight) {
+					t.Errorf("Expected right keys %v, got %v", expectedRight, keys)
+				}
+
+				if left.Size() != len(expectedLeft) || right.Size() != len(expectedRight) {
+					t.Errorf("Expected sizes %d and %d, got %d and %d", len(expectedLeft), len(expectedRight), left.Size(), right.Size())
+				}
+
+				if !tree.IsEmpty() || tree.Size() != 0 {
+					t.Error("Original tree should be empty after Split")
+				}
+
+				// join the halves back together
+				if err := left.Join(right); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+				verifyRedBlackProperties(t, left)
+
+				if keys := keysOf(left); len(keys) != n || left.Size() != n {
+					t.Errorf("Expected %d keys after Join, got %d (size %d)", n, len(keys), left.Size())
+				}
+
+				if !right.IsEmpty() || right.Size() != 0 {
+					t.Error("Joined tree should be empty after Join")
+				}
+			})
+		}
+	}
+
+	t.Run("split halves are independent", func(t *testing.T) {
+		tree := NewRedBlackTree[int, int]()
+		for i := 0; i < 100; i++ {
+			tree.Insert(i, i*10)
+		}
+
+		left, right := tree.Split(50)
+		for i := 0; i < 50; i += 2 {
+			left.Delete(i)
+			right.Delete(i + 50)
+			verifyRedBlackProperties(t, left)
+			verifyRedBlackProperties(t, right)
+		}
+
+		for i := 200; i < 220; i++ {
+			right.Insert(i, i*10)
+			verifyRedBlackProperties(t, right)
+		}
+
+		if left.Size() != 25 || right.Size() != 45 {
+			t.Errorf("Expected sizes 25 and 45, got %d and %d", left.Size(), right.Size())
+		}
+	})
+}
+
+func TestJoin(t *testing.T) {
+	t.Run("test join unrelated trees", func(t *testing.T) {
+		for _, sizes := range [][2]int{{0, 0}, {0, 5}, {5, 0}, {1, 1}, {1, 100}, {100, 1}, {50, 60}, {300, 3}} {
+			left := NewRedBlackTree[int, int]()
+			right := NewRedBlackTree[int, int]()
+			for i := 0; i < sizes[0]; i++ {
+				left.Insert(i, i)
+			}
+			for i := 0; i < sizes[1]; i++ {
+				right.Insert(1000+i, 1000+i)
+			}
+
+			if err := left.Join(right); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			verifyRedBlackProperties(t, left)
+
+			if left.Size() != sizes[0]+sizes[1] {
+				t.Errorf("Expected size %d, got %d", sizes[0]+sizes[1], left.Size())
+			}
+
+			if !right.IsEmpty() {
+				t.Error("Joined tree should be empty after Join")
+			}
+
+			// both trees must stay usable after the join
+			right.Insert(5000, 5000)
+			left.Insert(-1, -1)
+			left.Delete(0)
+			verifyRedBlackProperties(t, left)
+			verifyRedBlackProperties(t, right)
+		}
+	})
+
+	t.Run("test overlapping trees", func(t *testing.T) {
+		left := NewRedBlackTree[int, int]()
+		right := NewRedBlackTree[int, int]()
+		for i := 0; i < 10; i++ {
+			left.Insert(i, i)
+			right.Insert(i+9, i+9)
+		}
+
+		if err := left.Join(right); !errors.Is(err, ErrOverlappingKeys) {
+			t.Errorf("Expected ErrOverlappingKeys, got %v", err)
+		}
+
+		if left.Size() != 10 || right.Size() != 10 {
+			t.Error("Trees should be unchanged after failed Join")
+		}
+	})
+}
+
+func TestValidate(t *testing.T) {
+	newTree := func() *RedBlackTree[int, int] {
+		tree := NewRedBlackTree[int, int]()
+		for i := 1; i <= 20; i++ {
+			tree.Insert(i, i)
+		}
+		return tree
+	}
+
+	t.Run("test valid trees", func(t *testing.T) {
+		if err := NewRedBlackTree[int, int]().Validate(); err != nil {
+			t.Errorf("Empty tree should be valid, got %v", err)
+		}
+
+		if err := newTree().Validate(); err != nil {
+			t.Errorf("Tree should be valid, got %v", err)
+		}
+	})
+
+	testCases := []struct {
+		name    string
+		corrupt func(tree *RedBlackTree[int, int])
+		message string
+	}{
+		{"red root", func(tree *RedBlackTree[int, int]) {
+			tree.Root.NodeColor = RED
+		}, "root 8 is red"},
+		{"red red edge", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(18)
+			node.NodeColor = RED
+			node.Parent.NodeColor = RED
+		}, "has red child"},
+		{"black height", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(1)
+			node.NodeColor = RED
+		}, "black height mismatch at key 2"},
+		{"ordering", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(1)
+			node.Key = 100
+		}, "key 100 is not smaller than ancestor key 2"},
+		{"parent pointer", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(3)
+			node.Parent = tree.Root
+		}, "parent pointer of key 3"},
+		{"nil sentinel", func(tree *RedBlackTree[int, int]) {
+			tree.NIL.Parent = tree.Root
+		}, "NIL node is linked"},
+		{"nil child", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(20)
+			node.Right = nil
+		}, "node 20 has a nil child"},
+		{"tree size", func(tree *RedBlackTree[int, int]) {
+			tree.treeSize = 5
+		}, "tree size 5 does not match node count 20"},
+		{"subtree size", func(tree *RedBlackTree[int, int]) {
+			node, _ := tree.Search(20)
+			node.subtreeSize = 7
+		}, "subtree size of key 20 is 7"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tree := newTree()
+			tc.corrupt(tree)
+
+			err := tree.Validate()
+			if !errors.Is(err, ErrInvalidTree) {
+				t.Fatalf("Expected ErrInvalidTree, got %v", err)
+			}
+
+			if !strings.Contains(err.Error(), tc.message) {
+				t.Errorf("Expected error to mention %q, got %q", tc.message, err.Error())
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func TestWriteASCII(t *testing.T) {
+	tree := NewRedBlackTree[int, string]()
+	if tree.String() != "<empty>\n" {
+		t.Errorf("Unexpected rendering of empty tree %q", tree.String())
+	}
+
+	for i := 1; i <= 5; i++ {
+		tree.Insert(i, "value")
+	}
+
+	expected := strings.Join([]string{
+		"    ┌── 5 [R]",
+		"┌── 4 [B]",
+		"│   └── 3 [R]",
+		"2 [B]",
+		"└── 1 [B]",
+		"",
+	}, "\n")
+
+	var builder strings.Builder
+	if err := tree.WriteASCII(&builder); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if builder.String() != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, builder.String())
+	}
+
+	if tree.String() != expected {
+		t.Errorf("String should match WriteASCII, got:\n%s", tree.String())
+	}
+
+	if err := tree.WriteASCII(failingWriter{}); err == nil {
+		t.Error("Expected write error to be returned")
+	}
+}
+
+func TestWriteDOT(t *testing.T) {
+	tree := NewRedBlackTree[string, int]()
+	tree.Insert("b", 2)
+	tree.Insert("a", 1)
+	tree.Insert("c", 3)
+
+	var builder strings.Builder
+	if err := tree.WriteDOT(&builder); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	output := builder.String()
+	for _, line := range []string{
+		"digraph RedBlackTree {",
+		`n0 [label="b", fillcolor=black];`,
+		`n1 [label="a", fillcolor=red];`,
+		`n4 [label="c", fillcolor=red];`,
+		"n0 -> n1;",
+		"n0 -> n4;",
+		"n2 [shape=point, fillcolor=black];",
+		"n1 -> n2;",
+	} {
+		if !strings.Contains(output, line) {
+			t.Errorf("Expected DOT output to contain %q, got:\n%s", line, output)
+		}
+	}
+
+	if !strings.HasSuffix(output, "}\n") {
+		t.Error("DOT output should end with a closing brace")
+	}
+
+	if err := tree.WriteDOT(failingWriter{}); err == nil {
+		t.Error("Expected write error to be returned")
+	}
+}
@@ -0,0 +1,210 @@
+package trees
+
+/*
+	1. Segment tree keeps the aggregate of every segment of an array in a complete binary tree,
+	   the root covers the whole array and every leaf a single element.
+	2. Any range is covered by O(log n) segments, so range queries take O(log n) time.
+	3. Range updates are applied lazily, a segment which is covered completely only records
+	   the pending update and hands it down to it's children once they are visited.
+*/
+
+// LazyUpdate describes how a range update changes segment aggregates.
+// For example range add over sums is Apply: aggregate + delta*length, Compose: pending + delta,
+// and range assign over minimums is Apply: delta, Compose: delta.
+type LazyUpdate[T any] struct {
+	// Returns the aggregate of a segment of 'length' elements after 'delta' is applied to each of them.
+	Apply func(aggregate T, delta T, length int) T
+	// Returns a single delta with the same effect as applying 'pending' first and 'delta' after it.
+	Compose func(pending T, delta T) T
+}
+
+// SegmentTree answers range queries and applies range updates over an array in O(log n) time.
+// Ranges are half open, [l, r) covers indexes l to r-1, same as slicing.
+type SegmentTree[T any] struct {
+	// aggregates and pending updates of the segments, node i has children 2i+1 and 2i+2
+	aggregates []T
+	deltas     []T
+	pending    []bool
+	size       int
+	monoid     Monoid[T]
+	update     LazyUpdate[T]
+}
+
+// Returns a pointer to an empty SegmentTree.
+// 'monoid' combines the aggregates of neighbouring segments, 'update' applies range updates to them.
+func NewSegmentTree[T any](monoid Monoid[T], update LazyUpdate[T]) *SegmentTree[T] {
+	return &SegmentTree[T]{
+		monoid: monoid,
+		update: update,
+	}
+}
+
+// Replaces the contents of the tree with the given values.
+// Runs in O(n) time.
+func (s *SegmentTree[T]) Build(values []T) {
+	s.size = len(values)
+	nodes := 0
+	if s.size > 0 {
+		// 4n nodes are always enough for a tree over n values
+		nodes = 4 * s.size
+	}
+
+	s.aggregates = make([]T, nodes)
+	s.deltas = make([]T, nodes)
+	s.pending = make([]bool, nodes)
+
+	if s.size > 0 {
+		s.build(0, 0, s.size, values)
+	}
+}
+
+// Returns the aggregate of the values with indexes in [l, r).
+// Returns the monoid identity for an empty range.
+// Boolean is false if the range is out of bounds or l is greater than r.
+func (s *SegmentTree[T]) Query(l, r int) (T, bool) {
+	if l < 0 || r > s.size || l > r {
+		return s.monoid.Identity, false
+	}
+	if l == r {
+		return s.monoid.Identity, true
+	}
+	return s.query(0, 0, s.size, l, r), true
+}
+
+// Applies delta to every value with index in [l, r).
+// Returns false if the range is out of bounds or l is greater than r.
+func (s *SegmentTree[T]) Update(l, r int, delta T) bool {
+	if l < 0 || r > s.size || l > r {
+		return false
+	}
+	if l < r {
+		s.updateRange(0, 0, s.size, l, r, delta)
+	}
+	return true
+}
+
+// Replaces the value at index i.
+// Returns false if the index is out of range.
+func (s *SegmentTree[T]) Set(i int, value T) bool {
+	if i < 0 || i >= s.size {
+		return false
+	}
+	s.set(0, 0, s.size, i, value)
+	return true
+}
+
+// Returns the value at index i.
+// Boolean is false if the index is out of range.
+func (s *SegmentTree[T]) Get(i int) (T, bool) {
+	if i < 0 || i >= s.size {
+		return *new(T), false
+	}
+	return s.query(0, 0, s.size, i, i+1), true
+}
+
+// Returns the number of values in the tree.
+func (s *SegmentTree[T]) Size() int {
+	return s.size
+}
+
+// Builds the segment [lo, hi) rooted at 'node'.
+func (s *SegmentTree[T]) build(node, lo, hi int, values []T) {
+	if hi-lo == 1 {
+		s.aggregates[node] = values[lo]
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	s.build(2*node+1, lo, mid, values)
+	s.build(2*node+2, mid, hi, values)
+	s.pull(node)
+}
+
+// Returns the aggregate of [l, r) within the segment [lo, hi) rooted at 'node'.
+func (s *SegmentTree[T]) query(node, lo, hi, l, r int) T {
+	if l <= lo && hi <= r {
+		return s.aggregates[node]
+	}
+
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	result := s.monoid.Identity
+	if l < mid {
+		result = s.monoid.Combine(result, s.query(2*node+1, lo, mid, l, r))
+	}
+	if r > mid {
+		result = s.monoid.Combine(result, s.query(2*node+2, mid, hi, l, r))
+	}
+	return result
+}
+
+// Applies delta to [l, r) within the segment [lo, hi) rooted at 'node'.
+func (s *SegmentTree[T]) updateRange(node, lo, hi, l, r int, delta T) {
+	if l <= lo && hi <= r {
+		s.applyDelta(node, lo, hi, delta)
+		return
+	}
+
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	if l < mid {
+		s.updateRange(2*node+1, lo, mid, l, r, delta)
+	}
+	if r > mid {
+		s.updateRange(2*node+2, mid, hi, l, r, delta)
+	}
+	s.pull(node)
+}
+
+// Replaces the value at index i within the segment [lo, hi) rooted at 'node'.
+func (s *SegmentTree[T]) set(node, lo, hi, i int, value T) {
+	if hi-lo == 1 {
+		s.aggregates[node] = value
+		return
+	}
+
+	s.push(node, lo, hi)
+	mid := lo + (hi-lo)/2
+	if i < mid {
+		s.set(2*node+1, lo, mid, i, value)
+	} else {
+		s.set(2*node+2, mid, hi, i, value)
+	}
+	s.pull(node)
+}
+
+// Applies delta to the whole segment [lo, hi) rooted at 'node' and records it for the children.
+func (s *SegmentTree[T]) applyDelta(node, lo, hi int, delta T) {
+	s.aggregates[node] = s.update.Apply(s.aggregates[node], delta, hi-lo)
+
+	// leaves have no children to hand the update down to
+	if hi-lo == 1 {
+		return
+	}
+
+	if s.pending[node] {
+		s.deltas[node] = s.update.Compose(s.deltas[node], delta)
+	} else {
+		s.deltas[node] = delta
+		s.pending[node] = true
+	}
+}
+
+// Hands the pending update of 'node' down to it's children.
+func (s *SegmentTree[T]) push(node, lo, hi int) {
+	if !s.pending[node] {
+		return
+	}
+
+	mid := lo + (hi-lo)/2
+	s.applyDelta(2*node+1, lo, mid, s.deltas[node])
+	s.applyDelta(2*node+2, mid, hi, s.deltas[node])
+
+	s.deltas[node] = *new(T)
+	s.pending[node] = false
+}
+
+// Recomputes the aggregate of 'node' from it's children.
+func (s *SegmentTree[T]) pull(node int) {
+	s.aggregates[node] = s.monoid.Combine(s.aggregates[2*node+1], s.aggregates[2*node+2])
+}
@@ -0,0 +1,145 @@
+package trees
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+var (
+	// sumMonoid and maxMonoid are shared with the augmented tree tests
+	minMonoid = Monoid[int]{Identity: math.MaxInt, Combine: func(a, b int) int { return min(a, b) }}
+
+	addToSum = LazyUpdate[int]{
+		Apply:   func(aggregate, delta, length int) int { return aggregate + delta*length },
+		Compose: func(pending, delta int) int { return pending + delta },
+	}
+	addToExtreme = LazyUpdate[int]{
+		Apply:   func(aggregate, delta, _ int) int { return aggregate + delta },
+		Compose: func(pending, delta int) int { return pending + delta },
+	}
+	assignToSum = LazyUpdate[int]{
+		Apply:   func(_, delta, length int) int { return delta * length },
+		Compose: func(_, delta int) int { return delta },
+	}
+	assignToExtreme = LazyUpdate[int]{
+		Apply:   func(_, delta, _ int) int { return delta },
+		Compose: func(_, delta int) int { return delta },
+	}
+)
+
+// Checks the segment tree against a plain slice doing the same operations
+func TestSegmentTreeBruteForce(t *testing.T) {
+	tests := []struct {
+		name   string
+		monoid Monoid[int]
+		update LazyUpdate[int]
+		// applies the update to a single value of the reference slice
+		apply func(value, delta int) int
+	}{
+		{"sum with add", sumMonoid, addToSum, func(value, delta int) int { return value + delta }},
+		{"min with add", minMonoid, addToExtreme, func(value, delta int) int { return value + delta }},
+		{"max with add", maxMonoid, addToExtreme, func(value, delta int) int { return value + delta }},
+		{"sum with assign", sumMonoid, assignToSum, func(_, delta int) int { return delta }},
+		{"min with assign", minMonoid, assignToExtreme, func(_, delta int) int { return delta }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(3))
+
+			for _, size := range []int{1, 2, 7, 64, 100} {
+				reference := make([]int, size)
+				for i := range reference {
+					reference[i] = r.Intn(100) - 50
+				}
+				tree := NewSegmentTree(tt.monoid, tt.update)
+				tree.Build(reference)
+
+				for range 2000 {
+					l := r.Intn(size + 1)
+					end := l + r.Intn(size-l+1)
+
+					switch r.Intn(3) {
+					case 0:
+						delta := r.Intn(20) - 10
+						tree.Update(l, end, delta)
+						for i := l; i < end; i++ {
+							reference[i] = tt.apply(reference[i], delta)
+						}
+					case 1:
+						if l < size {
+							value := r.Intn(100) - 50
+							tree.Set(l, value)
+							reference[l] = value
+						}
+					case 2:
+						want := tt.monoid.Identity
+						for i := l; i < end; i++ {
+							want = tt.monoid.Combine(want, reference[i])
+						}
+						if got, ok := tree.Query(l, end); !ok || got != want {
+							t.Fatalf("Size %d: Query(%d, %d) = %d, %v, want %d", size, l, end, got, ok, want)
+						}
+					}
+				}
+
+				for i, want := range reference {
+					if got, ok := tree.Get(i); !ok || got != want {
+						t.Fatalf("Size %d: Get(%d) = %d, %v, want %d", size, i, got, ok, want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSegmentTreeBounds(t *testing.T) {
+	tree := NewSegmentTree(sumMonoid, addToSum)
+	if got, ok := tree.Query(0, 0); !ok || got != 0 {
+		t.Errorf("Empty query on empty tree = %d, %v, want 0, true", got, ok)
+	}
+	if tree.Set(0, 1) || tree.Update(0, 1, 1) {
+		t.Error("Updates on empty tree should return false")
+	}
+
+	tree.Build([]int{1, 2, 3, 4})
+	if tree.Size() != 4 {
+		t.Errorf("Expected size 4, got %d", tree.Size())
+	}
+
+	for _, bounds := range [][2]int{{-1, 2}, {0, 5}, {3, 2}} {
+		if _, ok := tree.Query(bounds[0], bounds[1]); ok {
+			t.Errorf("Query(%d, %d) should be out of bounds", bounds[0], bounds[1])
+		}
+		if tree.Update(bounds[0], bounds[1], 1) {
+			t.Errorf("Update(%d, %d) should be out of bounds", bounds[0], bounds[1])
+		}
+	}
+	if _, ok := tree.Get(4); ok || tree.Set(-1, 0) {
+		t.Error("Point access out of range should return false")
+	}
+	if got, _ := tree.Query(0, 4); got != 10 {
+		t.Errorf("Failed updates should not change the tree, got sum %d", got)
+	}
+
+	// rebuilding replaces the contents
+	tree.Build([]int{5})
+	if got, _ := tree.Query(0, 1); got != 5 || tree.Size() != 1 {
+		t.Errorf("Expected rebuilt tree with sum 5, got %d", got)
+	}
+}
+
+func BenchmarkSegmentTreeRangeAdd(b *testing.B) {
+	const size = 1 << 16
+	tree := NewSegmentTree(sumMonoid, addToSum)
+	tree.Build(make([]int, size))
+	r := rand.New(rand.NewSource(1))
+
+	for b.Loop() {
+		l := r.Intn(size)
+		end := l + r.Intn(size-l+1)
+		tree.Update(l, end, 1)
+		tree.Query(l, end)
+	}
+}
@@ -0,0 +1,368 @@
+package trees
+
+/*
+	1. Splay tree is a self adjusting binary search tree.
+	2. Every access moves the accessed node to the root with a series of rotations (splaying),
+	   so recently used keys are cheap to reach again.
+	3. There is no balance information, single operations can take O(n) time,
+	   but any sequence of m operations takes O(m log n) time.
+	4. Lookups restructure the tree, so even Search is not safe for concurrent use.
+*/
+
+import (
+	"cmp"
+	"iter"
+
+	"github.com/charmingbiswas/golang-stl/stack"
+)
+
+type splayNode[T any, V any] struct {
+	key   T
+	value V
+	left  *splayNode[T, V]
+	right *splayNode[T, V]
+}
+
+type SplayTree[T any, V any] struct {
+	root     *splayNode[T, V]
+	treeSize int
+	compare  func(a, b T) int
+}
+
+// Returns a pointer to an instance of a SplayTree struct.
+// Works with default built in types.
+func NewSplayTree[T cmp.Ordered, V any]() *SplayTree[T, V] {
+	return NewSplayTreeWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an instance of a SplayTree struct.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewSplayTreeWithFunc[T any, V any](comparator func(a, b T) int) *SplayTree[T, V] {
+	return &SplayTree[T, V]{
+		root:     nil,
+		treeSize: 0,
+		compare:  comparator,
+	}
+}
+
+// Inserts a key-value pair into the SplayTree.
+// If the key already exists, it's value is updated.
+// The key becomes the new root.
+func (s *SplayTree[T, V]) Insert(key T, value V) {
+	if s.root == nil {
+		s.root = &splayNode[T, V]{key: key, value: value}
+		s.treeSize++
+		return
+	}
+
+	s.root = s.splay(s.root, key)
+	result := s.compare(key, s.root.key)
+	if result == 0 {
+		s.root.value = value
+		return
+	}
+
+	// after splaying, the root is the closest key, it goes to one side of the new node
+	newNode := &splayNode[T, V]{key: key, value: value}
+	if result < 0 {
+		newNode.left = s.root.left
+		newNode.right = s.root
+		s.root.left = nil
+	} else {
+		newNode.right = s.root.right
+		newNode.left = s.root
+		s.root.right = nil
+	}
+
+	s.root = newNode
+	s.treeSize++
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (s *SplayTree[T, V]) Delete(key T) bool {
+	if s.root == nil {
+		return false
+	}
+
+	s.root = s.splay(s.root, key)
+	if s.compare(key, s.root.key) != 0 {
+		return false
+	}
+
+	if s.root.left == nil {
+		s.root = s.root.right
+	} else {
+		// every key on the left is smaller, so splaying for the deleted key brings the largest one up,
+		// which leaves it without a right child
+		right := s.root.right
+		s.root = s.splay(s.root.left, key)
+		s.root.right = right
+	}
+
+	s.treeSize--
+	return true
+}
+
+// Searches for a key in the tree.
+// Returns the value and boolean value.
+// Boolean is true if key is found, otherwise false.
+// The key, or the last key visited when it does not exist, becomes the new root.
+func (s *SplayTree[T, V]) Search(key T) (V, bool) {
+	if s.root == nil {
+		return *new(V), false
+	}
+
+	s.root = s.splay(s.root, key)
+	if s.compare(key, s.root.key) != 0 {
+		return *new(V), false
+	}
+	return s.root.value, true
+}
+
+// Returns the value stored for the key, same as Search.
+func (s *SplayTree[T, V]) Get(key T) (V, bool) {
+	return s.Search(key)
+}
+
+// Returns true if tree is empty, otherwise false.
+func (s *SplayTree[T, V]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Returns the current number of nodes in the tree.
+func (s *SplayTree[T, V]) Size() int {
+	return s.treeSize
+}
+
+// Clears and resets the tree to an empty tree.
+func (s *SplayTree[T, V]) Clear() {
+	s.root = nil
+	s.treeSize = 0
+}
+
+// Returns the smallest key and it's value.
+// Boolean is false if the tree is empty.
+// The smallest key becomes the new root.
+func (s *SplayTree[T, V]) Min() (T, V, bool) {
+	if s.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := s.root
+	for node.left != nil {
+		node = node.left
+	}
+
+	s.root = s.splay(s.root, node.key)
+	return s.root.key, s.root.value, true
+}
+
+// Returns the largest key and it's value.
+// Boolean is false if the tree is empty.
+// The largest key becomes the new root.
+func (s *SplayTree[T, V]) Max() (T, V, bool) {
+	if s.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := s.root
+	for node.right != nil {
+		node = node.right
+	}
+
+	s.root = s.splay(s.root, node.key)
+	return s.root.key, s.root.value, true
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+// Iteration does not restructure the tree.
+func (s *SplayTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*splayNode[T, V]]()
+		s.pushLeft(path, s.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			s.pushLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+// Iteration does not restructure the tree.
+func (s *SplayTree[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*splayNode[T, V]]()
+		s.pushRight(path, s.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			s.pushRight(path, node.left)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.Range.
+// Iteration does not restructure the tree.
+func (s *SplayTree[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		// descend to the first key in range, remembering every node we still need to visit
+		path := stack.NewStack[*splayNode[T, V]]()
+		for node := s.root; node != nil; {
+			result := s.compare(node.key, lo)
+			if result > 0 || (result == 0 && !excludeLow) {
+				path.Push(node)
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := s.compare(node.key, hi)
+			if result > 0 || (result == 0 && excludeHigh) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			s.pushLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi in descending order.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.RangeDesc.
+// Iteration does not restructure the tree.
+func (s *SplayTree[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*splayNode[T, V]]()
+		for node := s.root; node != nil; {
+			result := s.compare(node.key, hi)
+			if result < 0 || (result == 0 && !excludeHigh) {
+				path.Push(node)
+				node = node.right
+			} else {
+				node = node.left
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := s.compare(node.key, lo)
+			if result < 0 || (result == 0 && excludeLow) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			s.pushRight(path, node.left)
+		}
+	}
+}
+
+// Top down splay of the subtree rooted at 'node'.
+// Returns the new root, which holds the key if it exists,
+// otherwise the last node visited while searching for it.
+func (s *SplayTree[T, V]) splay(node *splayNode[T, V], key T) *splayNode[T, V] {
+	// header collects the left and right trees being assembled while walking down,
+	// header.right ends up as the left tree and header.left as the right tree
+	var header splayNode[T, V]
+	leftMax, rightMin := &header, &header
+
+	for {
+		result := s.compare(key, node.key)
+		if result < 0 {
+			if node.left == nil {
+				break
+			}
+			if s.compare(key, node.left.key) < 0 {
+				// zig-zig, rotate right first
+				child := node.left
+				node.left = child.right
+				child.right = node
+				node = child
+				if node.left == nil {
+					break
+				}
+			}
+			// link node into the right tree
+			rightMin.left = node
+			rightMin = node
+			node = node.left
+		} else if result > 0 {
+			if node.right == nil {
+				break
+			}
+			if s.compare(key, node.right.key) > 0 {
+				// zig-zig, rotate left first
+				child := node.right
+				node.right = child.left
+				child.left = node
+				node = child
+				if node.right == nil {
+					break
+				}
+			}
+			// link node into the left tree
+			leftMax.right = node
+			leftMax = node
+			node = node.right
+		} else {
+			break
+		}
+	}
+
+	// reassemble
+	leftMax.right = node.left
+	rightMin.left = node.right
+	node.left = header.right
+	node.right = header.left
+	return node
+}
+
+// Pushes 'node' and all of it's left descendants onto the stack.
+func (s *SplayTree[T, V]) pushLeft(path *stack.Stack[*splayNode[T, V]], node *splayNode[T, V]) {
+	for node != nil {
+		path.Push(node)
+		node = node.left
+	}
+}
+
+// Pushes 'node' and all of it's right descendants onto the stack.
+func (s *SplayTree[T, V]) pushRight(path *stack.Stack[*splayNode[T, V]], node *splayNode[T, V]) {
+	for node != nil {
+		path.Push(node)
+		node = node.right
+	}
+}
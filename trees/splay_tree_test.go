@@ -0,0 +1,151 @@
+package trees
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Helper function to verify the tree is a valid binary search tree of the right size
+func verifySplayTree[T any, V any](t *testing.T, tree *SplayTree[T, V]) {
+	count := 0
+	var previous *T
+	for key := range tree.ForwardIterator() {
+		if previous != nil && tree.compare(*previous, key) >= 0 {
+			t.Errorf("BST violation: %v followed by %v", *previous, key)
+		}
+		previous = &key
+		count++
+	}
+
+	if count != tree.Size() {
+		t.Errorf("Node count %d does not match tree size %d", count, tree.Size())
+	}
+}
+
+func TestSplayTreeAccessMovesToRoot(t *testing.T) {
+	tree := NewSplayTree[int, string]()
+	for i := range 100 {
+		tree.Insert(i, "")
+	}
+	if tree.root.key != 99 {
+		t.Errorf("Last inserted key should be the root, got %d", tree.root.key)
+	}
+
+	if _, ok := tree.Search(42); !ok || tree.root.key != 42 {
+		t.Errorf("Searched key should be the root, got %d", tree.root.key)
+	}
+	if _, ok := tree.Search(1000); ok || tree.root.key != 99 {
+		t.Errorf("Failed search should splay the closest key, got %d", tree.root.key)
+	}
+	if key, _, _ := tree.Min(); key != 0 || tree.root.key != 0 {
+		t.Errorf("Min should become the root, got %d", tree.root.key)
+	}
+	if key, _, _ := tree.Max(); key != 99 || tree.root.key != 99 {
+		t.Errorf("Max should become the root, got %d", tree.root.key)
+	}
+	verifySplayTree(t, tree)
+}
+
+func TestSplayTreeInsertDelete(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+	if tree.Delete(1) {
+		t.Error("Delete on empty tree should return false")
+	}
+	if _, ok := tree.Search(1); ok {
+		t.Error("Search on empty tree should return false")
+	}
+	if _, _, ok := tree.Max(); ok {
+		t.Error("Max on empty tree should return false")
+	}
+
+	keys := rand.New(rand.NewSource(1)).Perm(2000)
+	for _, key := range keys {
+		tree.Insert(key, key)
+	}
+	tree.Insert(keys[0], -1)
+	verifySplayTree(t, tree)
+
+	if value, _ := tree.Search(keys[0]); value != -1 {
+		t.Errorf("Expected updated value -1, got %d", value)
+	}
+
+	for i, key := range keys {
+		if i%2 == 1 {
+			continue
+		}
+		if !tree.Delete(key) {
+			t.Fatalf("Delete(%d) returned false", key)
+		}
+		if tree.Delete(key) {
+			t.Fatalf("Second Delete(%d) returned true", key)
+		}
+	}
+	verifySplayTree(t, tree)
+
+	if tree.Size() != 1000 {
+		t.Errorf("Expected size 1000, got %d", tree.Size())
+	}
+	for i, key := range keys {
+		if _, ok := tree.Get(key); ok != (i%2 == 1) {
+			t.Fatalf("Get(%d) found = %v, want %v", key, ok, i%2 == 1)
+		}
+	}
+}
+
+func TestSplayTreeDegenerateShape(t *testing.T) {
+	tree := NewSplayTree[int, int]()
+
+	// sequential inserts leave a single long path, iteration must not depend on recursion
+	for i := range 100_000 {
+		tree.Insert(i, i)
+	}
+
+	count := 0
+	for key := range tree.BackwardIterator() {
+		if key != tree.Size()-1-count {
+			t.Fatalf("Expected key %d, got %d", tree.Size()-1-count, key)
+		}
+		count++
+	}
+
+	var ranged []int
+	for key := range tree.Range(10, 15, ExcludeLow) {
+		ranged = append(ranged, key)
+	}
+	if want := []int{11, 12, 13, 14, 15}; !slices.Equal(ranged, want) {
+		t.Errorf("Range got %v, want %v", ranged, want)
+	}
+
+	// an access to the deepest key roughly halves the depth of the path
+	tree.Search(0)
+	verifySplayTree(t, tree)
+}
+
+// Most lookups go to a small set of hot keys, which the splay tree keeps near the root
+func BenchmarkHotKeyLookup(b *testing.B) {
+	keys := rand.New(rand.NewSource(1)).Perm(orderedMapBenchmarkSize)
+	r := rand.New(rand.NewSource(2))
+	lookups := make([]int, 10_000)
+	for i := range lookups {
+		if r.Intn(10) == 0 {
+			lookups[i] = keys[r.Intn(len(keys))]
+		} else {
+			lookups[i] = keys[r.Intn(16)]
+		}
+	}
+
+	for _, impl := range orderedMapImplementations {
+		m := impl.new()
+		for _, key := range keys {
+			m.Insert(key, key)
+		}
+		b.Run(impl.name, func(b *testing.B) {
+			for b.Loop() {
+				for _, key := range lookups {
+					m.Get(key)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+	"sync"
+)
+
+// SyncRedBlackTree is a RedBlackTree which is safe for concurrent use by multiple goroutines.
+// Reads share a read lock, writes take an exclusive lock.
+// Iterators walk a snapshot taken under the read lock, so the loop body may freely modify the tree.
+type SyncRedBlackTree[T any, V any] struct {
+	mu   sync.RWMutex
+	tree *RedBlackTree[T, V]
+}
+
+// Returns a pointer to an empty SyncRedBlackTree.
+// Works with default built in types.
+func NewSyncRedBlackTree[T cmp.Ordered, V any]() *SyncRedBlackTree[T, V] {
+	return NewSyncRedBlackTreeWithFunc[T, V](cmp.Compare[T])
+}
+
+// Returns a pointer to an empty SyncRedBlackTree.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewSyncRedBlackTreeWithFunc[T any, V any](comparator func(a, b T) int) *SyncRedBlackTree[T, V] {
+	return &SyncRedBlackTree[T, V]{
+		tree: NewRedBlackTreeWithFunc[T, V](comparator),
+	}
+}
+
+// Inserts a key-value pair into the tree.
+// If the key already exists, it's value is updated.
+func (s *SyncRedBlackTree[T, V]) Insert(key T, value V) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.Insert(key, value)
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (s *SyncRedBlackTree[T, V]) Delete(key T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.tree.Delete(key)
+}
+
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (s *SyncRedBlackTree[T, V]) Get(key T) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	node, ok := s.tree.Search(key)
+	if !ok {
+		return *new(V), false
+	}
+	return node.Value, true
+}
+
+// Returns true if the key exists in the tree.
+func (s *SyncRedBlackTree[T, V]) Contains(key T) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, ok := s.tree.Search(key)
+	return ok
+}
+
+// Atomically inserts or updates the value of a key.
+// 'update' receives the current value and whether the key exists, and returns the value to store.
+// Returns the stored value.
+// 'update' runs while the tree is locked, it must not call other methods of the tree.
+func (s *SyncRedBlackTree[T, V]) Upsert(key T, update func(value V, exists bool) V) V {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.tree.Search(key)
+	if ok {
+		node.Value = update(node.Value, true)
+		return node.Value
+	}
+
+	value := update(*new(V), false)
+	s.tree.Insert(key, value)
+	return value
+}
+
+// Atomically computes the new value of a key.
+// 'compute' receives the current value and whether the key exists,
+// and returns the new value and whether the key should be kept.
+// If keep is false the key is deleted, or not inserted if it did not exist.
+// Returns the new value and whether the key exists afterwards.
+// 'compute' runs while the tree is locked, it must not call other methods of the tree.
+func (s *SyncRedBlackTree[T, V]) Compute(key T, compute func(value V, exists bool) (V, bool)) (V, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	node, ok := s.tree.Search(key)
+	var current V
+	if ok {
+		current = node.Value
+	}
+
+	value, keep := compute(current, ok)
+	switch {
+	case !keep && ok:
+		s.tree.deleteNode(node)
+	case keep && ok:
+		node.Value = value
+	case keep && !ok:
+		s.tree.Insert(key, value)
+	}
+
+	if !keep {
+		return *new(V), false
+	}
+	return value, true
+}
+
+// Returns the current number of keys in the tree.
+func (s *SyncRedBlackTree[T, V]) Size() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.Size()
+}
+
+// Returns true if tree is empty, otherwise false.
+func (s *SyncRedBlackTree[T, V]) IsEmpty() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.tree.IsEmpty()
+}
+
+// Clears and resets the tree to an empty tree.
+func (s *SyncRedBlackTree[T, V]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tree.Clear()
+}
+
+// Returns a copy of the tree as a plain RedBlackTree.
+// The copy is independent, changes to it are not visible in this tree and vice versa.
+func (s *SyncRedBlackTree[T, V]) Snapshot() *RedBlackTree[T, V] {
+	s.mu.RLock()
+	keys, values := s.collect(s.tree.ForwardIterator())
+	s.mu.RUnlock()
+
+	snapshot := NewRedBlackTreeWithFunc[T, V](s.tree.compare)
+	snapshot.FromSorted(keys, values) // keys come out of a valid tree, sorted and unique
+	return snapshot
+}
+
+// Returns a 'push' iterator over a snapshot of the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (s *SyncRedBlackTree[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return s.snapshotIterator(func() iter.Seq2[T, V] { return s.tree.ForwardIterator() })
+}
+
+// Returns a 'push' iterator over a snapshot of the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (s *SyncRedBlackTree[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return s.snapshotIterator(func() iter.Seq2[T, V] { return s.tree.BackwardIterator() })
+}
+
+// Returns a 'push' iterator over a snapshot of all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.Range.
+func (s *SyncRedBlackTree[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	return s.snapshotIterator(func() iter.Seq2[T, V] { return s.tree.Range(lo, hi, opts...) })
+}
+
+// Returns a 'push' iterator over a snapshot of all keys in the interval between lo and hi in descending order.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.RangeDesc.
+func (s *SyncRedBlackTree[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	return s.snapshotIterator(func() iter.Seq2[T, V] { return s.tree.RangeDesc(hi, lo, opts...) })
+}
+
+// Returns an iterator which copies the pairs produced by 'source' under the read lock,
+// and yields them after the lock is released.
+// The snapshot is taken every time the iterator is used, not when it is created.
+func (s *SyncRedBlackTree[T, V]) snapshotIterator(source func() iter.Seq2[T, V]) iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		s.mu.RLock()
+		keys, values := s.collect(source())
+		s.mu.RUnlock()
+
+		for i := range keys {
+			if !yield(keys[i], values[i]) {
+				return
+			}
+		}
+	}
+}
+
+// Copies all pairs of the iterator into slices, the caller must hold the lock.
+func (s *SyncRedBlackTree[T, V]) collect(seq iter.Seq2[T, V]) ([]T, []V) {
+	keys := make([]T, 0)
+	values := make([]V, 0)
+	for key, value := range seq {
+		keys = append(keys, key)
+		values = append(values, value)
+	}
+	return keys, values
+}
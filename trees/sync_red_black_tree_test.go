@@ -0,0 +1,188 @@
+package trees
+
+import (
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestSyncRedBlackTree(t *testing.T) {
+	t.Run("test basic operations", func(t *testing.T) {
+		s := NewSyncRedBlackTree[int, string]()
+		s.Insert(2, "two")
+		s.Insert(1, "one")
+
+		if value, found := s.Get(2); !found || value != "two" {
+			t.Errorf("Expected value 'two', got %s", value)
+		}
+
+		if _, found := s.Get(3); found {
+			t.Error("Should not find missing key")
+		}
+
+		if !s.Contains(1) || s.Size() != 2 || s.IsEmpty() {
+			t.Error("Unexpected tree state after inserts")
+		}
+
+		if !s.Delete(1) || s.Delete(1) {
+			t.Error("Delete should succeed exactly once")
+		}
+
+		s.Clear()
+		if !s.IsEmpty() || s.Size() != 0 {
+			t.Error("Tree should be empty after Clear")
+		}
+	})
+
+	t.Run("test upsert", func(t *testing.T) {
+		s := NewSyncRedBlackTree[string, int]()
+		increment := func(value int, exists bool) int {
+			if !exists {
+				return 1
+			}
+			return value + 1
+		}
+
+		if value := s.Upsert("hits", increment); value != 1 {
+			t.Errorf("Expected 1 after first upsert, got %d", value)
+		}
+
+		if value := s.Upsert("hits", increment); value != 2 {
+			t.Errorf("Expected 2 after second upsert, got %d", value)
+		}
+	})
+
+	t.Run("test compute", func(t *testing.T) {
+		s := NewSyncRedBlackTree[string, int]()
+		s.Insert("a", 10)
+
+		value, present := s.Compute("a", func(value int, exists bool) (int, bool) {
+			return value * 2, exists
+		})
+		if !present || value != 20 {
+			t.Errorf("Expected 20, got %d", value)
+		}
+
+		if _, present := s.Compute("a", func(int, bool) (int, bool) { return 0, false }); present || s.Contains("a") {
+			t.Error("Compute returning keep=false should delete the key")
+		}
+
+		if _, present := s.Compute("b", func(int, bool) (int, bool) { return 0, false }); present || s.Contains("b") {
+			t.Error("Compute returning keep=false should not insert the key")
+		}
+
+		if value, present := s.Compute("c", func(value int, exists bool) (int, bool) { return 7, !exists }); !present || value != 7 {
+			t.Errorf("Expected inserted value 7, got %d", value)
+		}
+	})
+
+	t.Run("test snapshot iteration", func(t *testing.T) {
+		s := NewSyncRedBlackTree[int, int]()
+		for i := 0; i < 10; i++ {
+			s.Insert(i, i)
+		}
+
+		// modifying the tree inside the loop must not deadlock or affect the iteration
+		keys := make([]int, 0)
+		for key := range s.ForwardIterator() {
+			s.Delete(key)
+			s.Insert(key+100, key)
+			keys = append(keys, key)
+		}
+
+		if !slices.Equal(keys, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}) {
+			t.Errorf("Unexpected snapshot keys %v", keys)
+		}
+
+		keys = keys[:0]
+		for key := range s.Range(103, 105) {
+			keys = append(keys, key)
+		}
+
+		if !slices.Equal(keys, []int{103, 104, 105}) {
+			t.Errorf("Unexpected range keys %v", keys)
+		}
+
+		keys = keys[:0]
+		for key := range s.RangeDesc(105, 103) {
+			keys = append(keys, key)
+		}
+
+		if !slices.Equal(keys, []int{105, 104, 103}) {
+			t.Errorf("Unexpected descending range keys %v", keys)
+		}
+
+		keys = keys[:0]
+		for key := range s.BackwardIterator() {
+			keys = append(keys, key)
+			if len(keys) == 2 {
+				break
+			}
+		}
+
+		if !slices.Equal(keys, []int{109, 108}) {
+			t.Errorf("Unexpected backward keys %v", keys)
+		}
+
+		snapshot := s.Snapshot()
+		verifyRedBlackProperties(t, snapshot)
+		s.Clear()
+
+		if snapshot.Size() != 10 {
+			t.Errorf("Snapshot should not be affected by Clear, got size %d", snapshot.Size())
+		}
+	})
+}
+
+// Meant to be run with 'go test -race'.
+func TestSyncRedBlackTreeConcurrentAccess(t *testing.T) {
+	s := NewSyncRedBlackTree[int, int]()
+
+	const goroutines = 8
+	const operations = 500
+
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < operations; i++ {
+				key := (g*operations + i) % 100
+				switch i % 5 {
+				case 0:
+					s.Insert(key, i)
+				case 1:
+					s.Get(key)
+				case 2:
+					s.Upsert(key, func(value int, exists bool) int { return value + 1 })
+				case 3:
+					for range s.Range(key, key+10) {
+					}
+				case 4:
+					s.Delete(key)
+				}
+			}
+		}(g)
+	}
+
+	// concurrent counters must not lose updates
+	var counters sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		counters.Add(1)
+		go func() {
+			defer counters.Done()
+			for i := 0; i < operations; i++ {
+				s.Upsert(-1, func(value int, exists bool) int { return value + 1 })
+			}
+		}()
+	}
+
+	wg.Wait()
+	counters.Wait()
+
+	if value, _ := s.Get(-1); value != goroutines*operations {
+		t.Errorf("Expected counter %d, got %d", goroutines*operations, value)
+	}
+
+	verifyRedBlackProperties(t, s.Snapshot())
+}
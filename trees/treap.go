@@ -0,0 +1,608 @@
+package trees
+
+/*
+	1. Treap is a binary search tree on keys and a heap on random priorities at the same time.
+	2. Random priorities keep the expected height at O(log n) without any balancing rules.
+	3. All operations are built from two primitives, split and merge, which also makes
+	   splitting and joining whole trees cheap.
+	4. Priorities come from a seeded generator, so the shape of a tree is reproducible.
+*/
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+
+	"github.com/charmingbiswas/golang-stl/stack"
+)
+
+type treapNode[T any, V any] struct {
+	key      T
+	value    V
+	priority uint64
+	left     *treapNode[T, V]
+	right    *treapNode[T, V]
+	// number of nodes in the subtree rooted at this node, used for positions in implicit mode
+	size int
+	// children of this node still have to be swapped, only used in implicit mode
+	reversed bool
+}
+
+type Treap[T any, V any] struct {
+	root    *treapNode[T, V]
+	rng     *rand.Rand
+	compare func(a, b T) int
+}
+
+// ImplicitTreap is a treap keyed by position instead of by key, which makes it a sequence
+// supporting insertion, deletion and reversal at any index in O(log n) expected time.
+type ImplicitTreap[V any] struct {
+	root *treapNode[struct{}, V]
+	rng  *rand.Rand
+}
+
+// Returns a pointer to an instance of a Treap struct.
+// Works with default built in types.
+// Trees created with the same seed and the same operations have the same shape.
+func NewTreap[T cmp.Ordered, V any](seed uint64) *Treap[T, V] {
+	return NewTreapWithFunc[T, V](seed, cmp.Compare[T])
+}
+
+// Returns a pointer to an instance of a Treap struct.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewTreapWithFunc[T any, V any](seed uint64, comparator func(a, b T) int) *Treap[T, V] {
+	return &Treap[T, V]{
+		root:    nil,
+		rng:     rand.New(rand.NewPCG(seed, seed)),
+		compare: comparator,
+	}
+}
+
+// Returns a pointer to an instance of an ImplicitTreap struct.
+// Sequences created with the same seed and the same operations have the same shape.
+func NewImplicitTreap[V any](seed uint64) *ImplicitTreap[V] {
+	return &ImplicitTreap[V]{
+		root: nil,
+		rng:  rand.New(rand.NewPCG(seed, seed)),
+	}
+}
+
+// Inserts a key-value pair into the Treap.
+// If the key already exists, it's value is updated.
+func (t *Treap[T, V]) Insert(key T, value V) {
+	if node := t.search(key); node != nil {
+		node.value = value
+		return
+	}
+
+	left, right := t.split(t.root, key, false)
+	newNode := newTreapNode(t.rng, key, value)
+	t.root = treapMerge(treapMerge(left, newNode), right)
+}
+
+// Deletes a key from the tree.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+func (t *Treap[T, V]) Delete(key T) bool {
+	left, rest := t.split(t.root, key, false)
+	middle, right := t.split(rest, key, true)
+
+	t.root = treapMerge(left, right)
+	return middle != nil
+}
+
+// Searches for a key in the tree.
+// Returns the value and boolean value.
+// Boolean is true if key is found, otherwise false.
+func (t *Treap[T, V]) Search(key T) (V, bool) {
+	node := t.search(key)
+	if node == nil {
+		return *new(V), false
+	}
+	return node.value, true
+}
+
+// Returns the value stored for the key, same as Search.
+func (t *Treap[T, V]) Get(key T) (V, bool) {
+	return t.Search(key)
+}
+
+// Returns true if tree is empty, otherwise false.
+func (t *Treap[T, V]) IsEmpty() bool {
+	return t.root == nil
+}
+
+// Returns the current number of nodes in the tree.
+func (t *Treap[T, V]) Size() int {
+	return t.root.subtreeSize()
+}
+
+// Clears and resets the tree to an empty tree.
+// The priority generator keeps it's state.
+func (t *Treap[T, V]) Clear() {
+	t.root = nil
+}
+
+// Returns the smallest key and it's value.
+// Boolean is false if the tree is empty.
+func (t *Treap[T, V]) Min() (T, V, bool) {
+	if t.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := t.root
+	for node.left != nil {
+		node = node.left
+	}
+	return node.key, node.value, true
+}
+
+// Returns the largest key and it's value.
+// Boolean is false if the tree is empty.
+func (t *Treap[T, V]) Max() (T, V, bool) {
+	if t.root == nil {
+		return *new(T), *new(V), false
+	}
+
+	node := t.root
+	for node.right != nil {
+		node = node.right
+	}
+	return node.key, node.value, true
+}
+
+// Splits the tree into two trees at the given key.
+// Left tree contains all keys smaller than key, right tree contains all keys greater than or equal to key.
+// Nodes are moved, not copied, so the original tree is empty afterwards.
+// Both trees get their own priority generator seeded from this tree's generator.
+// Runs in O(log n) expected time.
+func (t *Treap[T, V]) Split(key T) (*Treap[T, V], *Treap[T, V]) {
+	left, right := NewTreapWithFunc[T, V](t.rng.Uint64(), t.compare), NewTreapWithFunc[T, V](t.rng.Uint64(), t.compare)
+	left.root, right.root = t.split(t.root, key, false)
+
+	t.Clear()
+	return left, right
+}
+
+// Moves all nodes of the other tree into this tree.
+// Every key of this tree must be smaller than every key of the other tree.
+// Returns an error if the key ranges overlap, both trees are left unchanged in that case.
+// The other tree is empty afterwards.
+// Runs in O(log n) expected time.
+func (t *Treap[T, V]) Join(other *Treap[T, V]) error {
+	if other.IsEmpty() {
+		return nil
+	}
+
+	if !t.IsEmpty() {
+		largest, _, _ := t.Max()
+		smallest, _, _ := other.Min()
+		if t.compare(largest, smallest) >= 0 {
+			return fmt.Errorf("%w: key %v is not smaller than key %v", ErrOverlappingKeys, largest, smallest)
+		}
+	}
+
+	t.root = treapMerge(t.root, other.root)
+	other.Clear()
+	return nil
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in 'InOrder' sorted ordering.
+func (t *Treap[T, V]) ForwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*treapNode[T, V]]()
+		pushTreapLeft(path, t.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			pushTreapLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator to the tree.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (t *Treap[T, V]) BackwardIterator() iter.Seq2[T, V] {
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*treapNode[T, V]]()
+		pushTreapRight(path, t.root)
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			pushTreapRight(path, node.left)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.Range.
+func (t *Treap[T, V]) Range(lo, hi T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		// descend to the first key in range, remembering every node we still need to visit
+		path := stack.NewStack[*treapNode[T, V]]()
+		for node := t.root; node != nil; {
+			result := t.compare(node.key, lo)
+			if result > 0 || (result == 0 && !excludeLow) {
+				path.Push(node)
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := t.compare(node.key, hi)
+			if result > 0 || (result == 0 && excludeHigh) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			pushTreapLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator over all keys in the interval between lo and hi in descending order.
+// Works with 'for range' expression.
+// Same bounds and options as RedBlackTree.RangeDesc.
+func (t *Treap[T, V]) RangeDesc(hi, lo T, opts ...RangeOption) iter.Seq2[T, V] {
+	excludeLow, excludeHigh := parseRangeOptions(opts)
+
+	return func(yield func(T, V) bool) {
+		path := stack.NewStack[*treapNode[T, V]]()
+		for node := t.root; node != nil; {
+			result := t.compare(node.key, hi)
+			if result < 0 || (result == 0 && !excludeHigh) {
+				path.Push(node)
+				node = node.right
+			} else {
+				node = node.left
+			}
+		}
+
+		for !path.IsEmpty() {
+			node := path.Top()
+			path.Pop()
+
+			result := t.compare(node.key, lo)
+			if result < 0 || (result == 0 && excludeLow) {
+				return
+			}
+
+			if !yield(node.key, node.value) {
+				return
+			}
+			pushTreapRight(path, node.left)
+		}
+	}
+}
+
+// Inserts value at the given index, shifting the following values one position up.
+// Index can range from 0 to Size(), returns false if it is out of range.
+func (s *ImplicitTreap[V]) InsertAt(index int, value V) bool {
+	if index < 0 || index > s.Size() {
+		return false
+	}
+
+	left, right := splitAt(s.root, index)
+	newNode := newTreapNode(s.rng, struct{}{}, value)
+	s.root = treapMerge(treapMerge(left, newNode), right)
+	return true
+}
+
+// Adds value at the end of the sequence.
+func (s *ImplicitTreap[V]) Append(value V) {
+	s.root = treapMerge(s.root, newTreapNode(s.rng, struct{}{}, value))
+}
+
+// Removes the value at the given index and returns it, shifting the following values one position down.
+// Boolean is false if the index is out of range.
+func (s *ImplicitTreap[V]) DeleteAt(index int) (V, bool) {
+	if index < 0 || index >= s.Size() {
+		return *new(V), false
+	}
+
+	left, rest := splitAt(s.root, index)
+	middle, right := splitAt(rest, 1)
+	s.root = treapMerge(left, right)
+	return middle.value, true
+}
+
+// Returns the value at the given index.
+// Boolean is false if the index is out of range.
+func (s *ImplicitTreap[V]) Get(index int) (V, bool) {
+	node := s.nodeAt(index)
+	if node == nil {
+		return *new(V), false
+	}
+	return node.value, true
+}
+
+// Replaces the value at the given index.
+// Returns false if the index is out of range.
+func (s *ImplicitTreap[V]) Set(index int, value V) bool {
+	node := s.nodeAt(index)
+	if node == nil {
+		return false
+	}
+	node.value = value
+	return true
+}
+
+// Reverses the order of values with indexes in [from, to).
+// Returns false if the range is out of bounds or from is greater than to.
+// Runs in O(log n) expected time, the reversal is pushed down lazily.
+func (s *ImplicitTreap[V]) Reverse(from, to int) bool {
+	if from < 0 || to > s.Size() || from > to {
+		return false
+	}
+
+	left, rest := splitAt(s.root, from)
+	middle, right := splitAt(rest, to-from)
+	if middle != nil {
+		middle.reversed = !middle.reversed
+	}
+	s.root = treapMerge(treapMerge(left, middle), right)
+	return true
+}
+
+// Splits the sequence into two sequences at the given index.
+// Left sequence contains values with indexes smaller than index, right sequence contains the rest.
+// Index is clamped to the bounds of the sequence.
+// Nodes are moved, not copied, so the original sequence is empty afterwards.
+// Runs in O(log n) expected time.
+func (s *ImplicitTreap[V]) SplitAt(index int) (*ImplicitTreap[V], *ImplicitTreap[V]) {
+	left, right := NewImplicitTreap[V](s.rng.Uint64()), NewImplicitTreap[V](s.rng.Uint64())
+	left.root, right.root = splitAt(s.root, max(0, min(index, s.Size())))
+
+	s.Clear()
+	return left, right
+}
+
+// Appends all values of the other sequence to this sequence.
+// The other sequence is empty afterwards.
+// Runs in O(log n) expected time.
+func (s *ImplicitTreap[V]) Concat(other *ImplicitTreap[V]) {
+	s.root = treapMerge(s.root, other.root)
+	other.Clear()
+}
+
+// Returns true if sequence is empty, otherwise false.
+func (s *ImplicitTreap[V]) IsEmpty() bool {
+	return s.root == nil
+}
+
+// Returns the current number of values in the sequence.
+func (s *ImplicitTreap[V]) Size() int {
+	return s.root.subtreeSize()
+}
+
+// Clears and resets the sequence to an empty sequence.
+// The priority generator keeps it's state.
+func (s *ImplicitTreap[V]) Clear() {
+	s.root = nil
+}
+
+// Returns a 'push' iterator to the sequence.
+// Works with 'for range' expression.
+// Returns index-value pair per iteration from the first to the last position.
+func (s *ImplicitTreap[V]) ForwardIterator() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		path := stack.NewStack[*treapNode[struct{}, V]]()
+		pushTreapLeft(path, s.root)
+
+		for index := 0; !path.IsEmpty(); index++ {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(index, node.value) {
+				return
+			}
+			pushTreapLeft(path, node.right)
+		}
+	}
+}
+
+// Returns a 'push' iterator to the sequence.
+// Works with 'for range' expression.
+// Returns index-value pair per iteration from the last to the first position.
+func (s *ImplicitTreap[V]) BackwardIterator() iter.Seq2[int, V] {
+	return func(yield func(int, V) bool) {
+		path := stack.NewStack[*treapNode[struct{}, V]]()
+		pushTreapRight(path, s.root)
+
+		for index := s.Size() - 1; !path.IsEmpty(); index-- {
+			node := path.Top()
+			path.Pop()
+
+			if !yield(index, node.value) {
+				return
+			}
+			pushTreapRight(path, node.left)
+		}
+	}
+}
+
+// Returns the node at the given index, nil if the index is out of range.
+func (s *ImplicitTreap[V]) nodeAt(index int) *treapNode[struct{}, V] {
+	if index < 0 || index >= s.Size() {
+		return nil
+	}
+
+	node := s.root
+	for {
+		node.push()
+		leftSize := node.left.subtreeSize()
+		if index < leftSize {
+			node = node.left
+		} else if index > leftSize {
+			// skip the left subtree and the current node
+			index -= leftSize + 1
+			node = node.right
+		} else {
+			return node
+		}
+	}
+}
+
+// Returns the node with the given key, nil if it does not exist.
+func (t *Treap[T, V]) search(key T) *treapNode[T, V] {
+	node := t.root
+
+	for node != nil {
+		result := t.compare(key, node.key)
+		if result == 0 {
+			return node
+		} else if result < 0 {
+			node = node.left
+		} else {
+			node = node.right
+		}
+	}
+
+	return nil
+}
+
+// Splits the subtree rooted at 'node' into keys smaller than key and the rest.
+// With 'inclusive' the key itself goes to the left part as well.
+func (t *Treap[T, V]) split(node *treapNode[T, V], key T, inclusive bool) (*treapNode[T, V], *treapNode[T, V]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	result := t.compare(node.key, key)
+	if result < 0 || (result == 0 && inclusive) {
+		// node and it's left subtree go left, the right subtree has to be split further
+		left, right := t.split(node.right, key, inclusive)
+		node.right = left
+		node.update()
+		return node, right
+	}
+
+	left, right := t.split(node.left, key, inclusive)
+	node.left = right
+	node.update()
+	return left, node
+}
+
+// Splits the subtree rooted at 'node' into it's first 'count' nodes and the rest.
+func splitAt[V any](node *treapNode[struct{}, V], count int) (*treapNode[struct{}, V], *treapNode[struct{}, V]) {
+	if node == nil {
+		return nil, nil
+	}
+
+	node.push()
+	leftSize := node.left.subtreeSize()
+	if count <= leftSize {
+		left, right := splitAt(node.left, count)
+		node.left = right
+		node.update()
+		return left, node
+	}
+
+	left, right := splitAt(node.right, count-leftSize-1)
+	node.right = left
+	node.update()
+	return node, right
+}
+
+// Merges two subtrees, every node of 'left' must come before every node of 'right'.
+// Returns the root of the merged subtree.
+func treapMerge[T any, V any](left, right *treapNode[T, V]) *treapNode[T, V] {
+	if left == nil {
+		return right
+	}
+	if right == nil {
+		return left
+	}
+
+	// higher priority stays on top
+	if left.priority > right.priority {
+		left.push()
+		left.right = treapMerge(left.right, right)
+		left.update()
+		return left
+	}
+
+	right.push()
+	right.left = treapMerge(left, right.left)
+	right.update()
+	return right
+}
+
+func newTreapNode[T any, V any](rng *rand.Rand, key T, value V) *treapNode[T, V] {
+	return &treapNode[T, V]{
+		key:      key,
+		value:    value,
+		priority: rng.Uint64(),
+		size:     1,
+	}
+}
+
+// Pushes 'node' and all of it's left descendants onto the stack.
+func pushTreapLeft[T any, V any](path *stack.Stack[*treapNode[T, V]], node *treapNode[T, V]) {
+	for node != nil {
+		node.push()
+		path.Push(node)
+		node = node.left
+	}
+}
+
+// Pushes 'node' and all of it's right descendants onto the stack.
+func pushTreapRight[T any, V any](path *stack.Stack[*treapNode[T, V]], node *treapNode[T, V]) {
+	for node != nil {
+		node.push()
+		path.Push(node)
+		node = node.right
+	}
+}
+
+// Hands a pending reversal down to the children.
+func (n *treapNode[T, V]) push() {
+	if !n.reversed {
+		return
+	}
+
+	n.left, n.right = n.right, n.left
+	if n.left != nil {
+		n.left.reversed = !n.left.reversed
+	}
+	if n.right != nil {
+		n.right.reversed = !n.right.reversed
+	}
+	n.reversed = false
+}
+
+func (n *treapNode[T, V]) update() {
+	n.size = n.left.subtreeSize() + n.right.subtreeSize() + 1
+}
+
+// Returns the size of the subtree, 0 for nil.
+func (n *treapNode[T, V]) subtreeSize() int {
+	if n == nil {
+		return 0
+	}
+	return n.size
+}
@@ -0,0 +1,287 @@
+package trees
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+// Helper function to verify treap properties
+func verifyTreapProperties[T any, V any](t *testing.T, tree *Treap[T, V]) {
+	verifyTreapNode(t, tree.root, tree.compare)
+}
+
+// Checks key ordering, heap ordering of priorities and subtree sizes, returns the number of nodes in the subtree.
+// A nil comparator skips the key ordering check, which is the case for implicit treaps.
+func verifyTreapNode[T any, V any](t *testing.T, node *treapNode[T, V], compare func(a, b T) int) int {
+	if node == nil {
+		return 0
+	}
+
+	for _, child := range []*treapNode[T, V]{node.left, node.right} {
+		if child != nil && child.priority > node.priority {
+			t.Errorf("Heap violation: child priority %d above parent priority %d", child.priority, node.priority)
+		}
+	}
+	if compare != nil {
+		if node.left != nil && compare(node.left.key, node.key) >= 0 {
+			t.Errorf("BST violation: left child %v of %v", node.left.key, node.key)
+		}
+		if node.right != nil && compare(node.right.key, node.key) <= 0 {
+			t.Errorf("BST violation: right child %v of %v", node.right.key, node.key)
+		}
+	}
+
+	size := verifyTreapNode(t, node.left, compare) + verifyTreapNode(t, node.right, compare) + 1
+	if node.size != size {
+		t.Errorf("Subtree size mismatch (stored: %d, actual: %d)", node.size, size)
+	}
+	return size
+}
+
+func treapKeys[T any, V any](tree *Treap[T, V]) []T {
+	var keys []T
+	for key := range tree.ForwardIterator() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestTreapInsertDelete(t *testing.T) {
+	tree := NewTreap[int, int](7)
+	if tree.Delete(1) {
+		t.Error("Delete on empty tree should return false")
+	}
+	if _, _, ok := tree.Min(); ok {
+		t.Error("Min on empty tree should return false")
+	}
+
+	keys := rand.New(rand.NewSource(1)).Perm(1000)
+	for _, key := range keys {
+		tree.Insert(key, key*2)
+	}
+	tree.Insert(10, -1)
+	verifyTreapProperties(t, tree)
+
+	if tree.Size() != 1000 {
+		t.Errorf("Expected size 1000, got %d", tree.Size())
+	}
+	if value, ok := tree.Search(10); !ok || value != -1 {
+		t.Errorf("Expected updated value -1, got %d, %v", value, ok)
+	}
+	if key, _, _ := tree.Min(); key != 0 {
+		t.Errorf("Min = %d, want 0", key)
+	}
+	if key, _, _ := tree.Max(); key != 999 {
+		t.Errorf("Max = %d, want 999", key)
+	}
+
+	for _, key := range keys[:500] {
+		if !tree.Delete(key) {
+			t.Fatalf("Delete(%d) returned false", key)
+		}
+	}
+	verifyTreapProperties(t, tree)
+
+	want := slices.Clone(keys[500:])
+	slices.Sort(want)
+	if got := treapKeys(tree); !slices.Equal(got, want) {
+		t.Errorf("Got keys %v, want %v", got, want)
+	}
+}
+
+func TestTreapDeterministicSeed(t *testing.T) {
+	build := func(seed uint64) *Treap[int, int] {
+		tree := NewTreap[int, int](seed)
+		for i := range 100 {
+			tree.Insert(i, i)
+		}
+		return tree
+	}
+
+	// walks both trees in pre order and compares their shapes
+	var sameShape func(a, b *treapNode[int, int]) bool
+	sameShape = func(a, b *treapNode[int, int]) bool {
+		if a == nil || b == nil {
+			return a == b
+		}
+		return a.key == b.key && a.priority == b.priority && sameShape(a.left, b.left) && sameShape(a.right, b.right)
+	}
+
+	if !sameShape(build(42).root, build(42).root) {
+		t.Error("Trees built with the same seed should have the same shape")
+	}
+	if sameShape(build(42).root, build(43).root) {
+		t.Error("Trees built with different seeds should differ")
+	}
+}
+
+func TestTreapSplitJoin(t *testing.T) {
+	tree := NewTreap[int, string](1)
+	for i := range 100 {
+		tree.Insert(i, "")
+	}
+
+	left, right := tree.Split(40)
+	if !tree.IsEmpty() {
+		t.Error("Original tree should be empty after Split")
+	}
+	verifyTreapProperties(t, left)
+	verifyTreapProperties(t, right)
+	if left.Size() != 40 || right.Size() != 60 {
+		t.Fatalf("Expected sizes 40 and 60, got %d and %d", left.Size(), right.Size())
+	}
+	if key, _, _ := right.Min(); key != 40 {
+		t.Errorf("Right tree should start at 40, got %d", key)
+	}
+
+	if err := right.Join(left); !errors.Is(err, ErrOverlappingKeys) {
+		t.Errorf("Expected ErrOverlappingKeys, got %v", err)
+	}
+	if left.Size() != 40 || right.Size() != 60 {
+		t.Error("Failed Join should leave both trees unchanged")
+	}
+
+	if err := left.Join(right); err != nil {
+		t.Fatalf("Join failed: %v", err)
+	}
+	verifyTreapProperties(t, left)
+	if !right.IsEmpty() || left.Size() != 100 {
+		t.Errorf("Expected all keys in the left tree, got %d and %d", left.Size(), right.Size())
+	}
+
+	// halves keep working on their own
+	left.Insert(1000, "x")
+	if _, ok := left.Search(1000); !ok {
+		t.Error("Joined tree should accept inserts")
+	}
+}
+
+// Checks the implicit treap against a plain slice doing the same operations
+func TestImplicitTreap(t *testing.T) {
+	seq := NewImplicitTreap[int](3)
+	var reference []int
+	r := rand.New(rand.NewSource(5))
+
+	contents := func() []int {
+		var values []int
+		for _, value := range seq.ForwardIterator() {
+			values = append(values, value)
+		}
+		return values
+	}
+
+	for i := range 3000 {
+		switch op := r.Intn(6); {
+		case op < 2:
+			index := r.Intn(len(reference) + 1)
+			seq.InsertAt(index, i)
+			reference = slices.Insert(reference, index, i)
+		case op == 2:
+			seq.Append(i)
+			reference = append(reference, i)
+		case op == 3 && len(reference) > 0:
+			index := r.Intn(len(reference))
+			value, ok := seq.DeleteAt(index)
+			if !ok || value != reference[index] {
+				t.Fatalf("DeleteAt(%d) = %d, %v, want %d", index, value, ok, reference[index])
+			}
+			reference = slices.Delete(reference, index, index+1)
+		case op == 4:
+			from := r.Intn(len(reference) + 1)
+			to := from + r.Intn(len(reference)-from+1)
+			seq.Reverse(from, to)
+			slices.Reverse(reference[from:to])
+		case op == 5 && len(reference) > 0:
+			index := r.Intn(len(reference))
+			seq.Set(index, -i)
+			reference[index] = -i
+		}
+
+		if i%300 == 0 {
+			verifyTreapNode(t, seq.root, nil)
+			if got := contents(); !slices.Equal(got, reference) {
+				t.Fatalf("After %d operations got %v, want %v", i, got, reference)
+			}
+		}
+	}
+
+	if seq.Size() != len(reference) {
+		t.Fatalf("Size = %d, want %d", seq.Size(), len(reference))
+	}
+	for index, want := range reference {
+		if got, ok := seq.Get(index); !ok || got != want {
+			t.Fatalf("Get(%d) = %d, %v, want %d", index, got, ok, want)
+		}
+	}
+
+	var backward []int
+	for index, value := range seq.BackwardIterator() {
+		if reference[index] != value {
+			t.Fatalf("BackwardIterator gave %d at index %d, want %d", value, index, reference[index])
+		}
+		backward = append(backward, value)
+	}
+	if len(backward) != len(reference) {
+		t.Errorf("BackwardIterator gave %d values, want %d", len(backward), len(reference))
+	}
+}
+
+func TestImplicitTreapBounds(t *testing.T) {
+	seq := NewImplicitTreap[string](1)
+	if _, ok := seq.Get(0); ok {
+		t.Error("Get on empty sequence should return false")
+	}
+	if _, ok := seq.DeleteAt(0); ok {
+		t.Error("DeleteAt on empty sequence should return false")
+	}
+	if seq.InsertAt(1, "a") || seq.InsertAt(-1, "a") {
+		t.Error("InsertAt out of range should return false")
+	}
+	if !seq.InsertAt(0, "a") || !seq.InsertAt(1, "b") {
+		t.Error("InsertAt within range should return true")
+	}
+	if seq.Set(2, "c") || seq.Reverse(1, 3) || seq.Reverse(2, 1) {
+		t.Error("Out of range Set and Reverse should return false")
+	}
+	if !seq.Reverse(0, 2) || !seq.Reverse(1, 1) {
+		t.Error("Reverse within range should return true")
+	}
+	if value, _ := seq.Get(0); value != "b" {
+		t.Errorf("Expected b first after reversal, got %s", value)
+	}
+}
+
+func TestImplicitTreapSplitConcat(t *testing.T) {
+	seq := NewImplicitTreap[int](9)
+	for i := range 10 {
+		seq.Append(i)
+	}
+	seq.Reverse(0, 10)
+
+	left, right := seq.SplitAt(4)
+	if !seq.IsEmpty() {
+		t.Error("Original sequence should be empty after SplitAt")
+	}
+	if left.Size() != 4 || right.Size() != 6 {
+		t.Fatalf("Expected sizes 4 and 6, got %d and %d", left.Size(), right.Size())
+	}
+
+	// put the halves back in swapped order
+	right.Concat(left)
+	var got []int
+	for _, value := range right.ForwardIterator() {
+		got = append(got, value)
+	}
+	if want := []int{5, 4, 3, 2, 1, 0, 9, 8, 7, 6}; !slices.Equal(got, want) {
+		t.Errorf("Got %v, want %v", got, want)
+	}
+	if !left.IsEmpty() {
+		t.Error("Concatenated sequence should be empty")
+	}
+
+	if l, r := right.SplitAt(100); l.Size() != 10 || r.Size() != 0 {
+		t.Errorf("SplitAt past the end should clamp, got sizes %d and %d", l.Size(), r.Size())
+	}
+}
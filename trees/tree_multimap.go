@@ -0,0 +1,169 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// TreeMultiMap is an ordered map which allows duplicate keys, similar to C++ std::multimap.
+// Values sharing the same key are kept in insertion order.
+type TreeMultiMap[K any, V any] struct {
+	tree *RedBlackTree[K, []V]
+	size int
+}
+
+// Returns a pointer to an empty TreeMultiMap.
+// Works with default built in types.
+func NewTreeMultiMap[K cmp.Ordered, V any]() *TreeMultiMap[K, V] {
+	return NewTreeMultiMapWithFunc[K, V](cmp.Compare[K])
+}
+
+// Returns a pointer to an empty TreeMultiMap.
+// Works with any custom key type as defined by the user.
+// Takes a comparator function that defines the ordering of the keys, same as NewRedBlackTreeWithFunc.
+func NewTreeMultiMapWithFunc[K any, V any](comparator func(a, b K) int) *TreeMultiMap[K, V] {
+	return &TreeMultiMap[K, V]{
+		tree: NewRedBlackTreeWithFunc[K, []V](comparator),
+		size: 0,
+	}
+}
+
+// Inserts a key-value pair into the multimap.
+// Existing values for the same key are kept, the new value is placed after them.
+func (m *TreeMultiMap[K, V]) Insert(key K, value V) {
+	node, ok := m.tree.Search(key)
+	if ok {
+		node.Value = append(node.Value, value)
+	} else {
+		m.tree.Insert(key, []V{value})
+	}
+	m.size++
+}
+
+// Returns all values stored for the key in insertion order.
+// Returns nil if key does not exist.
+// The returned slice is a copy and can be modified freely.
+func (m *TreeMultiMap[K, V]) Get(key K) []V {
+	node, ok := m.tree.Search(key)
+	if !ok {
+		return nil
+	}
+	return append([]V(nil), node.Value...)
+}
+
+// Returns true if at least one value is stored for the key.
+func (m *TreeMultiMap[K, V]) Contains(key K) bool {
+	_, ok := m.tree.Search(key)
+	return ok
+}
+
+// Returns the number of values stored for the key.
+func (m *TreeMultiMap[K, V]) Count(key K) int {
+	node, ok := m.tree.Search(key)
+	if !ok {
+		return 0
+	}
+	return len(node.Value)
+}
+
+// Removes the oldest value stored for the key.
+// Returns false if key does not exist.
+func (m *TreeMultiMap[K, V]) DeleteOne(key K) bool {
+	node, ok := m.tree.Search(key)
+	if !ok {
+		return false
+	}
+
+	if len(node.Value) == 1 {
+		m.tree.deleteNode(node)
+	} else {
+		// clear the reference so the removed value can be garbage collected
+		node.Value[0] = *new(V)
+		node.Value = node.Value[1:]
+	}
+	m.size--
+	return true
+}
+
+// Removes every value stored for the key.
+// Returns the number of values removed.
+func (m *TreeMultiMap[K, V]) DeleteAll(key K) int {
+	node, ok := m.tree.Search(key)
+	if !ok {
+		return 0
+	}
+
+	removed := len(node.Value)
+	m.tree.deleteNode(node)
+	m.size -= removed
+	return removed
+}
+
+// Returns a 'push' iterator over all values stored for the key.
+// Works with 'for range' expression.
+// Values are returned in insertion order.
+func (m *TreeMultiMap[K, V]) EqualRange(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		node, ok := m.tree.Search(key)
+		if !ok {
+			return
+		}
+
+		for _, value := range node.Value {
+			if !yield(node.Key, value) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the multimap.
+// Works with 'for range' expression.
+// Keys are returned in sorted order, values of the same key in insertion order.
+func (m *TreeMultiMap[K, V]) ForwardIterator() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.tree.ForwardIterator() {
+			for _, value := range values {
+				if !yield(key, value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the multimap.
+// Works with 'for range' expression.
+// Keys are returned in reverse sorted order, values of the same key in reverse insertion order.
+func (m *TreeMultiMap[K, V]) BackwardIterator() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, values := range m.tree.BackwardIterator() {
+			for i := len(values) - 1; i >= 0; i-- {
+				if !yield(key, values[i]) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Returns the total number of values in the multimap, counting duplicates.
+func (m *TreeMultiMap[K, V]) Size() int {
+	return m.size
+}
+
+// Returns the number of distinct keys in the multimap.
+func (m *TreeMultiMap[K, V]) KeyCount() int {
+	return m.tree.Size()
+}
+
+// Returns true if multimap is empty, otherwise false.
+func (m *TreeMultiMap[K, V]) IsEmpty() bool {
+	return m.size == 0
+}
+
+// Clears and resets the multimap to an empty multimap.
+func (m *TreeMultiMap[K, V]) Clear() {
+	m.tree.Clear()
+	m.size = 0
+}
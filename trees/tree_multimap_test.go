@@ -0,0 +1,125 @@
+package trees
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTreeMultiMap(t *testing.T) {
+	t.Run("test duplicates keep insertion order", func(t *testing.T) {
+		m := NewTreeMultiMap[int, string]()
+		m.Insert(20, "b")
+		m.Insert(10, "a")
+		m.Insert(20, "c")
+		m.Insert(30, "d")
+		m.Insert(20, "e")
+
+		if m.Size() != 5 {
+			t.Errorf("Expected size 5, got %d", m.Size())
+		}
+
+		if m.KeyCount() != 3 {
+			t.Errorf("Expected 3 distinct keys, got %d", m.KeyCount())
+		}
+
+		if m.Count(20) != 3 {
+			t.Errorf("Expected count 3 for key 20, got %d", m.Count(20))
+		}
+
+		if m.Count(40) != 0 {
+			t.Errorf("Expected count 0 for missing key, got %d", m.Count(40))
+		}
+
+		if values := m.Get(20); !slices.Equal(values, []string{"b", "c", "e"}) {
+			t.Errorf("Expected values [b c e], got %v", values)
+		}
+
+		if values := m.Get(40); values != nil {
+			t.Errorf("Expected nil for missing key, got %v", values)
+		}
+
+		values := make([]string, 0)
+		for key, value := range m.EqualRange(20) {
+			if key != 20 {
+				t.Errorf("Expected key 20, got %d", key)
+			}
+			values = append(values, value)
+		}
+
+		if !slices.Equal(values, []string{"b", "c", "e"}) {
+			t.Errorf("Expected EqualRange [b c e], got %v", values)
+		}
+	})
+
+	t.Run("test iterators", func(t *testing.T) {
+		m := NewTreeMultiMap[int, string]()
+		m.Insert(2, "x")
+		m.Insert(1, "a")
+		m.Insert(2, "y")
+
+		forward := make([]string, 0)
+		for _, value := range m.ForwardIterator() {
+			forward = append(forward, value)
+		}
+
+		if !slices.Equal(forward, []string{"a", "x", "y"}) {
+			t.Errorf("Expected forward [a x y], got %v", forward)
+		}
+
+		backward := make([]string, 0)
+		for _, value := range m.BackwardIterator() {
+			backward = append(backward, value)
+		}
+
+		if !slices.Equal(backward, []string{"y", "x", "a"}) {
+			t.Errorf("Expected backward [y x a], got %v", backward)
+		}
+	})
+
+	t.Run("test delete one and delete all", func(t *testing.T) {
+		m := NewTreeMultiMap[int, string]()
+		m.Insert(1, "first")
+		m.Insert(1, "second")
+		m.Insert(1, "third")
+		m.Insert(2, "only")
+
+		if !m.DeleteOne(1) {
+			t.Error("Failed to delete one value of key 1")
+		}
+
+		if values := m.Get(1); !slices.Equal(values, []string{"second", "third"}) {
+			t.Errorf("Expected oldest value removed, got %v", values)
+		}
+
+		if removed := m.DeleteAll(1); removed != 2 {
+			t.Errorf("Expected 2 values removed, got %d", removed)
+		}
+
+		if m.Contains(1) {
+			t.Error("Key 1 should not exist after DeleteAll")
+		}
+
+		if !m.DeleteOne(2) || m.Contains(2) {
+			t.Error("Deleting the last value should remove the key")
+		}
+
+		if m.DeleteOne(2) || m.DeleteAll(2) != 0 {
+			t.Error("Deleting a missing key should do nothing")
+		}
+
+		if !m.IsEmpty() || m.Size() != 0 {
+			t.Errorf("Expected empty multimap, got size %d", m.Size())
+		}
+	})
+
+	t.Run("test clear", func(t *testing.T) {
+		m := NewTreeMultiMap[string, int]()
+		m.Insert("a", 1)
+		m.Insert("a", 2)
+		m.Clear()
+
+		if !m.IsEmpty() || m.Size() != 0 || m.KeyCount() != 0 {
+			t.Error("Multimap should be empty after Clear")
+		}
+	})
+}
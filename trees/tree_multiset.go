@@ -0,0 +1,110 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// TreeMultiSet is an ordered set which allows duplicate elements, similar to C++ std::multiset.
+// Elements which compare equal are kept in insertion order.
+type TreeMultiSet[T any] struct {
+	// every element is stored as the value of it's own key,
+	// so elements which compare equal but are not identical are preserved
+	data *TreeMultiMap[T, T]
+}
+
+// Returns a pointer to an empty TreeMultiSet.
+// Works with default built in types.
+func NewTreeMultiSet[T cmp.Ordered]() *TreeMultiSet[T] {
+	return NewTreeMultiSetWithFunc(cmp.Compare[T])
+}
+
+// Returns a pointer to an empty TreeMultiSet.
+// Works with any custom type as defined by the user.
+// Takes a comparator function that defines the ordering of the elements, same as NewRedBlackTreeWithFunc.
+func NewTreeMultiSetWithFunc[T any](comparator func(a, b T) int) *TreeMultiSet[T] {
+	return &TreeMultiSet[T]{
+		data: NewTreeMultiMapWithFunc[T, T](comparator),
+	}
+}
+
+// Adds an element to the multiset.
+func (s *TreeMultiSet[T]) Insert(val T) {
+	s.data.Insert(val, val)
+}
+
+// Returns true if at least one element equal to val exists.
+func (s *TreeMultiSet[T]) Contains(val T) bool {
+	return s.data.Contains(val)
+}
+
+// Returns the number of elements equal to val.
+func (s *TreeMultiSet[T]) Count(val T) int {
+	return s.data.Count(val)
+}
+
+// Removes the oldest element equal to val.
+// Returns false if no such element exists.
+func (s *TreeMultiSet[T]) DeleteOne(val T) bool {
+	return s.data.DeleteOne(val)
+}
+
+// Removes every element equal to val.
+// Returns the number of elements removed.
+func (s *TreeMultiSet[T]) DeleteAll(val T) int {
+	return s.data.DeleteAll(val)
+}
+
+// Returns a 'push' iterator over all elements equal to val.
+// Works with 'for range' expression.
+// Elements are returned in insertion order.
+func (s *TreeMultiSet[T]) EqualRange(val T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.data.EqualRange(val) {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the multiset.
+// Works with 'for range' expression.
+// Elements are returned in sorted order, equal elements in insertion order.
+func (s *TreeMultiSet[T]) ForwardIterator() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.data.ForwardIterator() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the multiset.
+// Works with 'for range' expression.
+// Elements are returned in reverse sorted order, equal elements in reverse insertion order.
+func (s *TreeMultiSet[T]) BackwardIterator() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, element := range s.data.BackwardIterator() {
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Returns the total number of elements in the multiset, counting duplicates.
+func (s *TreeMultiSet[T]) Size() int {
+	return s.data.Size()
+}
+
+// Returns true if multiset is empty, otherwise false.
+func (s *TreeMultiSet[T]) IsEmpty() bool {
+	return s.data.IsEmpty()
+}
+
+// Clears and resets the multiset to an empty multiset.
+func (s *TreeMultiSet[T]) Clear() {
+	s.data.Clear()
+}
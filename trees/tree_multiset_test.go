@@ -0,0 +1,69 @@
+package trees
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTreeMultiSet(t *testing.T) {
+	t.Run("test duplicates", func(t *testing.T) {
+		s := NewTreeMultiSet[int]()
+		for _, val := range []int{5, 1, 5, 3, 5, 1} {
+			s.Insert(val)
+		}
+
+		if s.Size() != 6 {
+			t.Errorf("Expected size 6, got %d", s.Size())
+		}
+
+		if s.Count(5) != 3 || s.Count(1) != 2 || s.Count(3) != 1 {
+			t.Errorf("Unexpected counts: 5=%d, 1=%d, 3=%d", s.Count(5), s.Count(1), s.Count(3))
+		}
+
+		if !slices.Equal(slices.Collect(s.ForwardIterator()), []int{1, 1, 3, 5, 5, 5}) {
+			t.Errorf("Unexpected forward order %v", slices.Collect(s.ForwardIterator()))
+		}
+
+		if !slices.Equal(slices.Collect(s.BackwardIterator()), []int{5, 5, 5, 3, 1, 1}) {
+			t.Errorf("Unexpected backward order %v", slices.Collect(s.BackwardIterator()))
+		}
+
+		if !s.DeleteOne(5) || s.Count(5) != 2 {
+			t.Errorf("Expected count 2 after DeleteOne, got %d", s.Count(5))
+		}
+
+		if removed := s.DeleteAll(1); removed != 2 || s.Contains(1) {
+			t.Errorf("Expected 2 elements removed, got %d", removed)
+		}
+
+		if s.Size() != 3 {
+			t.Errorf("Expected size 3, got %d", s.Size())
+		}
+	})
+
+	t.Run("test equal elements keep insertion order", func(t *testing.T) {
+		s := NewTreeMultiSetWithFunc(func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+
+		s.Insert("Go")
+		s.Insert("apple")
+		s.Insert("GO")
+		s.Insert("go")
+
+		if equal := slices.Collect(s.EqualRange("gO")); !slices.Equal(equal, []string{"Go", "GO", "go"}) {
+			t.Errorf("Expected [Go GO go], got %v", equal)
+		}
+
+		s.DeleteOne("go")
+		if equal := slices.Collect(s.EqualRange("go")); !slices.Equal(equal, []string{"GO", "go"}) {
+			t.Errorf("Expected [GO go], got %v", equal)
+		}
+
+		s.Clear()
+		if !s.IsEmpty() {
+			t.Error("Multiset should be empty after Clear")
+		}
+	})
+}
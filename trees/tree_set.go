@@ -0,0 +1,203 @@
+package trees
+
+import (
+	"cmp"
+	"iter"
+)
+
+// TreeSet is an ordered set of unique elements, similar to C++ std::set.
+// Set algebra operations walk both sets in sorted order and run in linear time.
+type TreeSet[T any] struct {
+	tree *RedBlackTree[T, struct{}]
+}
+
+// Returns a pointer to an empty TreeSet.
+// Works with default built in types.
+func NewTreeSet[T cmp.Ordered]() *TreeSet[T] {
+	return NewTreeSetWithFunc(cmp.Compare[T])
+}
+
+// Returns a pointer to an empty TreeSet.
+// Works with any custom type as defined by the user.
+// Takes a comparator function that defines the ordering of the elements, same as NewRedBlackTreeWithFunc.
+func NewTreeSetWithFunc[T any](comparator func(a, b T) int) *TreeSet[T] {
+	return &TreeSet[T]{
+		tree: NewRedBlackTreeWithFunc[T, struct{}](comparator),
+	}
+}
+
+// Adds an element to the set.
+// Does nothing if the element already exists.
+func (s *TreeSet[T]) Add(val T) {
+	s.tree.Insert(val, struct{}{})
+}
+
+// Removes an element from the set.
+// Returns false if the element does not exist.
+func (s *TreeSet[T]) Remove(val T) bool {
+	return s.tree.Delete(val)
+}
+
+// Returns true if the element exists in the set.
+func (s *TreeSet[T]) Contains(val T) bool {
+	_, ok := s.tree.Search(val)
+	return ok
+}
+
+// Returns the number of elements in the set.
+func (s *TreeSet[T]) Size() int {
+	return s.tree.Size()
+}
+
+// Returns true if set is empty, otherwise false.
+func (s *TreeSet[T]) IsEmpty() bool {
+	return s.tree.IsEmpty()
+}
+
+// Clears and resets the set to an empty set.
+func (s *TreeSet[T]) Clear() {
+	s.tree.Clear()
+}
+
+// Returns a 'push' iterator to the set.
+// Works with 'for range' expression.
+// Elements are returned in sorted order.
+func (s *TreeSet[T]) ForwardIterator() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for val := range s.tree.ForwardIterator() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a 'push' iterator to the set.
+// Works with 'for range' expression.
+// Elements are returned in reverse sorted order.
+func (s *TreeSet[T]) BackwardIterator() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for val := range s.tree.BackwardIterator() {
+			if !yield(val) {
+				return
+			}
+		}
+	}
+}
+
+// Returns a new set with elements present in either set.
+func (s *TreeSet[T]) Union(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, true, true)
+}
+
+// Returns a new set with elements present in both sets.
+func (s *TreeSet[T]) Intersection(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, false, true, false)
+}
+
+// Returns a new set with elements present in this set but not in the other.
+func (s *TreeSet[T]) Difference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, false, false)
+}
+
+// Returns a new set with elements present in exactly one of the sets.
+func (s *TreeSet[T]) SymmetricDifference(other *TreeSet[T]) *TreeSet[T] {
+	return s.merge(other, true, false, true)
+}
+
+// Returns true if every element of this set is present in the other set.
+func (s *TreeSet[T]) IsSubset(other *TreeSet[T]) bool {
+	if s.Size() > other.Size() {
+		return false
+	}
+
+	subset := true
+	s.walk(other, func(val T, inThis, inOther bool) bool {
+		if inThis && !inOther {
+			subset = false
+		}
+		return subset
+	})
+	return subset
+}
+
+// Returns true if the sets have no element in common.
+func (s *TreeSet[T]) IsDisjoint(other *TreeSet[T]) bool {
+	disjoint := true
+	s.walk(other, func(val T, inThis, inOther bool) bool {
+		if inThis && inOther {
+			disjoint = false
+		}
+		return disjoint
+	})
+	return disjoint
+}
+
+// Builds a new set by merging both sets in sorted order.
+// The flags decide which elements end up in the result:
+// elements only in this set, elements in both sets and elements only in the other set.
+func (s *TreeSet[T]) merge(other *TreeSet[T], keepThisOnly, keepBoth, keepOtherOnly bool) *TreeSet[T] {
+	elements := make([]T, 0)
+
+	s.walk(other, func(val T, inThis, inOther bool) bool {
+		if (inThis && inOther && keepBoth) ||
+			(inThis && !inOther && keepThisOnly) ||
+			(!inThis && inOther && keepOtherOnly) {
+			elements = append(elements, val)
+		}
+		return true
+	})
+
+	// elements are produced in sorted order without duplicates, so bulk construction can not fail
+	result := NewTreeSetWithFunc(s.tree.compare)
+	result.tree.FromSorted(elements, make([]struct{}, len(elements)))
+	return result
+}
+
+// Walks both sets together in sorted order, like the merge step of merge sort.
+// Calls visit once per distinct element, telling which of the sets contain it.
+// Stops early if visit returns false.
+func (s *TreeSet[T]) walk(other *TreeSet[T], visit func(val T, inThis, inOther bool) bool) {
+	nextThis, stopThis := iter.Pull(s.ForwardIterator())
+	defer stopThis()
+	nextOther, stopOther := iter.Pull(other.ForwardIterator())
+	defer stopOther()
+
+	a, okA := nextThis()
+	b, okB := nextOther()
+
+	for okA && okB {
+		result := s.tree.compare(a, b)
+		if result < 0 {
+			if !visit(a, true, false) {
+				return
+			}
+			a, okA = nextThis()
+		} else if result > 0 {
+			if !visit(b, false, true) {
+				return
+			}
+			b, okB = nextOther()
+		} else {
+			if !visit(a, true, true) {
+				return
+			}
+			a, okA = nextThis()
+			b, okB = nextOther()
+		}
+	}
+
+	for okA {
+		if !visit(a, true, false) {
+			return
+		}
+		a, okA = nextThis()
+	}
+
+	for okB {
+		if !visit(b, false, true) {
+			return
+		}
+		b, okB = nextOther()
+	}
+}
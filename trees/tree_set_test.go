@@ -0,0 +1,118 @@
+package trees
+
+import (
+	"slices"
+	"testing"
+)
+
+func newTreeSetFrom(values ...int) *TreeSet[int] {
+	s := NewTreeSet[int]()
+	for _, val := range values {
+		s.Add(val)
+	}
+	return s
+}
+
+func TestTreeSet(t *testing.T) {
+	s := NewTreeSet[int]()
+	for _, val := range []int{5, 1, 3, 5, 1} {
+		s.Add(val)
+	}
+
+	if s.Size() != 3 {
+		t.Errorf("Expected size 3, got %d", s.Size())
+	}
+
+	if !s.Contains(3) || s.Contains(4) {
+		t.Error("Contains returned wrong result")
+	}
+
+	if !slices.Equal(slices.Collect(s.ForwardIterator()), []int{1, 3, 5}) {
+		t.Errorf("Unexpected forward order %v", slices.Collect(s.ForwardIterator()))
+	}
+
+	if !slices.Equal(slices.Collect(s.BackwardIterator()), []int{5, 3, 1}) {
+		t.Errorf("Unexpected backward order %v", slices.Collect(s.BackwardIterator()))
+	}
+
+	if !s.Remove(3) || s.Remove(3) {
+		t.Error("Remove should succeed exactly once")
+	}
+
+	s.Clear()
+	if !s.IsEmpty() {
+		t.Error("Set should be empty after Clear")
+	}
+}
+
+func TestTreeSetAlgebra(t *testing.T) {
+	a := newTreeSetFrom(1, 2, 3, 4, 5)
+	b := newTreeSetFrom(4, 5, 6, 7)
+	empty := NewTreeSet[int]()
+
+	testCases := []struct {
+		name     string
+		result   *TreeSet[int]
+		expected []int
+	}{
+		{"union", a.Union(b), []int{1, 2, 3, 4, 5, 6, 7}},
+		{"intersection", a.Intersection(b), []int{4, 5}},
+		{"difference", a.Difference(b), []int{1, 2, 3}},
+		{"reverse difference", b.Difference(a), []int{6, 7}},
+		{"symmetric difference", a.SymmetricDifference(b), []int{1, 2, 3, 6, 7}},
+		{"union with empty", a.Union(empty), []int{1, 2, 3, 4, 5}},
+		{"intersection with empty", a.Intersection(empty), []int{}},
+		{"empty difference", empty.Difference(a), []int{}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := slices.Collect(tc.result.ForwardIterator())
+			if !slices.Equal(result, tc.expected) {
+				t.Errorf("Expected %v, got %v", tc.expected, result)
+			}
+
+			if tc.result.Size() != len(tc.expected) {
+				t.Errorf("Expected size %d, got %d", len(tc.expected), tc.result.Size())
+			}
+		})
+	}
+
+	t.Run("operands are unchanged", func(t *testing.T) {
+		if !slices.Equal(slices.Collect(a.ForwardIterator()), []int{1, 2, 3, 4, 5}) {
+			t.Error("Set algebra should not modify the receiver")
+		}
+
+		if !slices.Equal(slices.Collect(b.ForwardIterator()), []int{4, 5, 6, 7}) {
+			t.Error("Set algebra should not modify the argument")
+		}
+	})
+
+	t.Run("subset", func(t *testing.T) {
+		if !newTreeSetFrom(2, 4).IsSubset(a) {
+			t.Error("{2, 4} should be a subset of a")
+		}
+
+		if newTreeSetFrom(2, 6).IsSubset(a) {
+			t.Error("{2, 6} should not be a subset of a")
+		}
+
+		if !empty.IsSubset(a) || !a.IsSubset(a) {
+			t.Error("Empty set and the set itself should be subsets")
+		}
+
+		if a.IsSubset(newTreeSetFrom(1, 2)) {
+			t.Error("Bigger set should not be a subset")
+		}
+	})
+
+	t.Run("disjoint", func(t *testing.T) {
+		if a.IsDisjoint(b) {
+			t.Error("a and b share elements")
+		}
+
+		if !a.IsDisjoint(newTreeSetFrom(10, 11)) || !a.IsDisjoint(empty) {
+			t.Error("Sets without common elements should be disjoint")
+		}
+	})
+}
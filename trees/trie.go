@@ -0,0 +1,264 @@
+package trees
+
+/*
+	1. Trie (prefix tree) stores string keys one unit per level, keys sharing a prefix share the path for it.
+	2. Units are either bytes or runes, decided when the trie is created.
+	   Byte-wise tries accept any string, rune-wise tries branch once per character
+	   and expect valid UTF-8, invalid bytes are stored as utf8.RuneError.
+	3. Children are kept sorted, so iteration is in the same order as comparing keys with '<'.
+	4. Lookups take O(len(key)) time, independent of the number of keys.
+*/
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"unicode/utf8"
+)
+
+type trieNode[V any] struct {
+	unit     rune
+	value    V
+	hasValue bool
+	children []*trieNode[V]
+}
+
+type Trie[V any] struct {
+	root     *trieNode[V]
+	runes    bool
+	treeSize int
+}
+
+// Returns a pointer to an instance of a Trie struct.
+// Keys are split into bytes.
+func NewTrie[V any]() *Trie[V] {
+	return &Trie[V]{
+		root:     &trieNode[V]{},
+		runes:    false,
+		treeSize: 0,
+	}
+}
+
+// Returns a pointer to an instance of a Trie struct.
+// Keys are split into runes.
+func NewRuneTrie[V any]() *Trie[V] {
+	return &Trie[V]{
+		root:     &trieNode[V]{},
+		runes:    true,
+		treeSize: 0,
+	}
+}
+
+// Inserts a key-value pair into the Trie.
+// If the key already exists, it's value is updated.
+func (t *Trie[V]) Insert(key string, value V) {
+	node := t.root
+
+	for len(key) > 0 {
+		unit, width := nextKeyUnit(key, t.runes)
+		i, found := node.find(unit)
+		if !found {
+			node.children = slices.Insert(node.children, i, &trieNode[V]{unit: unit})
+		}
+		node = node.children[i]
+		key = key[width:]
+	}
+
+	if !node.hasValue {
+		t.treeSize++
+	}
+	node.value = value
+	node.hasValue = true
+}
+
+// Deletes a key from the trie.
+// If key does not exist, returns false, otherwise true if deletion is successful.
+// Nodes which no longer lead to any key are removed.
+func (t *Trie[V]) Delete(key string) bool {
+	// remember the path, so empty nodes can be cut off on the way back up
+	path := []*trieNode[V]{t.root}
+	node := t.root
+
+	for len(key) > 0 {
+		unit, width := nextKeyUnit(key, t.runes)
+		i, found := node.find(unit)
+		if !found {
+			return false
+		}
+		node = node.children[i]
+		path = append(path, node)
+		key = key[width:]
+	}
+
+	if !node.hasValue {
+		return false
+	}
+
+	node.value = *new(V)
+	node.hasValue = false
+	t.treeSize--
+
+	for depth := len(path) - 1; depth > 0; depth-- {
+		child := path[depth]
+		if child.hasValue || len(child.children) > 0 {
+			break
+		}
+		parent := path[depth-1]
+		i, _ := parent.find(child.unit)
+		parent.children = slices.Delete(parent.children, i, i+1)
+	}
+	return true
+}
+
+// Returns the value stored for the key.
+// Boolean is true if key is found, otherwise false.
+func (t *Trie[V]) Get(key string) (V, bool) {
+	node := t.root
+
+	for len(key) > 0 {
+		unit, width := nextKeyUnit(key, t.runes)
+		i, found := node.find(unit)
+		if !found {
+			return *new(V), false
+		}
+		node = node.children[i]
+		key = key[width:]
+	}
+
+	return node.value, node.hasValue
+}
+
+// Returns the longest stored key which is a prefix of the given key, and it's value.
+// Boolean is false if no stored key is a prefix of the key.
+func (t *Trie[V]) LongestPrefixMatch(key string) (string, V, bool) {
+	node := t.root
+	var match *trieNode[V]
+	matched := 0
+	if node.hasValue {
+		match = node
+	}
+
+	for consumed := 0; consumed < len(key); {
+		unit, width := nextKeyUnit(key[consumed:], t.runes)
+		i, found := node.find(unit)
+		if !found {
+			break
+		}
+		node = node.children[i]
+		consumed += width
+
+		if node.hasValue {
+			match, matched = node, consumed
+		}
+	}
+
+	if match == nil {
+		return "", *new(V), false
+	}
+	return key[:matched], match.value, true
+}
+
+// Returns a 'push' iterator over all keys starting with the given prefix.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in sorted ordering.
+func (t *Trie[V]) WalkPrefix(prefix string) iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		node := t.root
+		key := make([]byte, 0, len(prefix))
+
+		for rest := prefix; len(rest) > 0; {
+			unit, width := nextKeyUnit(rest, t.runes)
+			i, found := node.find(unit)
+			if !found {
+				return
+			}
+			node = node.children[i]
+			key = appendKeyUnit(key, unit, t.runes)
+			rest = rest[width:]
+		}
+
+		t.walk(node, key, false, yield)
+	}
+}
+
+// Returns a 'push' iterator to the trie.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in sorted ordering.
+func (t *Trie[V]) ForwardIterator() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.walk(t.root, nil, false, yield)
+	}
+}
+
+// Returns a 'push' iterator to the trie.
+// Works with 'for range' expression.
+// Returns key-value pair per iteration in reverse sorted ordering.
+func (t *Trie[V]) BackwardIterator() iter.Seq2[string, V] {
+	return func(yield func(string, V) bool) {
+		t.walk(t.root, nil, true, yield)
+	}
+}
+
+// Returns true if trie is empty, otherwise false.
+func (t *Trie[V]) IsEmpty() bool {
+	return t.treeSize == 0
+}
+
+// Returns the current number of keys in the trie.
+func (t *Trie[V]) Size() int {
+	return t.treeSize
+}
+
+// Clears and resets the trie to an empty trie.
+func (t *Trie[V]) Clear() {
+	t.root = &trieNode[V]{}
+	t.treeSize = 0
+}
+
+// Yields all keys in the subtree rooted at 'node', 'key' holds the path to the node.
+// Returns false if iteration was stopped.
+func (t *Trie[V]) walk(node *trieNode[V], key []byte, reverse bool, yield func(string, V) bool) bool {
+	// a key comes before all keys it is a prefix of
+	if !reverse && node.hasValue && !yield(string(key), node.value) {
+		return false
+	}
+
+	for i := range node.children {
+		child := node.children[i]
+		if reverse {
+			child = node.children[len(node.children)-1-i]
+		}
+		if !t.walk(child, appendKeyUnit(key, child.unit, t.runes), reverse, yield) {
+			return false
+		}
+	}
+
+	if reverse && node.hasValue && !yield(string(key), node.value) {
+		return false
+	}
+	return true
+}
+
+// Returns the index of the child with the given unit, or where it would be inserted,
+// and whether that child exists.
+func (n *trieNode[V]) find(unit rune) (int, bool) {
+	return slices.BinarySearchFunc(n.children, unit, func(child *trieNode[V], unit rune) int {
+		return cmp.Compare(child.unit, unit)
+	})
+}
+
+// Returns the first unit of a non empty key and it's width in bytes.
+func nextKeyUnit(key string, runes bool) (rune, int) {
+	if !runes {
+		return rune(key[0]), 1
+	}
+	return utf8.DecodeRuneInString(key)
+}
+
+// Appends the encoding of the unit to the key.
+func appendKeyUnit(key []byte, unit rune, runes bool) []byte {
+	if !runes {
+		return append(key, byte(unit))
+	}
+	return utf8.AppendRune(key, unit)
+}
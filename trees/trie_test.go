@@ -0,0 +1,307 @@
+package trees
+
+import (
+	"iter"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// Method set shared by Trie and RadixTree, so both are checked by the same tests
+type prefixTree[V any] interface {
+	Insert(key string, value V)
+	Delete(key string) bool
+	Get(key string) (V, bool)
+	LongestPrefixMatch(key string) (string, V, bool)
+	WalkPrefix(prefix string) iter.Seq2[string, V]
+	ForwardIterator() iter.Seq2[string, V]
+	BackwardIterator() iter.Seq2[string, V]
+	Size() int
+	IsEmpty() bool
+	Clear()
+}
+
+var prefixTreeImplementations = []struct {
+	name string
+	new  func() prefixTree[int]
+}{
+	{"Trie", func() prefixTree[int] { return NewTrie[int]() }},
+	{"RuneTrie", func() prefixTree[int] { return NewRuneTrie[int]() }},
+	{"RadixTree", func() prefixTree[int] { return NewRadixTree[int]() }},
+	{"RuneRadixTree", func() prefixTree[int] { return NewRuneRadixTree[int]() }},
+}
+
+func collectKeys(seq iter.Seq2[string, int]) []string {
+	var keys []string
+	for key := range seq {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+func TestPrefixTreeBasic(t *testing.T) {
+	for _, impl := range prefixTreeImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			tree := impl.new()
+			if !tree.IsEmpty() {
+				t.Error("New tree should be empty")
+			}
+			if tree.Delete("a") {
+				t.Error("Delete on empty tree should return false")
+			}
+
+			keys := []string{"romane", "romanus", "romulus", "rubens", "ruber", "rubicon", "rubicundus", "rom", "r", ""}
+			for i, key := range keys {
+				tree.Insert(key, i)
+			}
+			tree.Insert("rubens", 100)
+
+			if tree.Size() != len(keys) {
+				t.Errorf("Expected size %d, got %d", len(keys), tree.Size())
+			}
+			for i, key := range keys {
+				want := i
+				if key == "rubens" {
+					want = 100
+				}
+				if value, ok := tree.Get(key); !ok || value != want {
+					t.Errorf("Get(%q) = %d, %v, want %d, true", key, value, ok, want)
+				}
+			}
+			for _, missing := range []string{"ro", "roman", "rubicons", "x"} {
+				if _, ok := tree.Get(missing); ok {
+					t.Errorf("Get(%q) should not find anything", missing)
+				}
+			}
+
+			sorted := slices.Clone(keys)
+			sort.Strings(sorted)
+			if got := collectKeys(tree.ForwardIterator()); !slices.Equal(got, sorted) {
+				t.Errorf("ForwardIterator = %q, want %q", got, sorted)
+			}
+			slices.Reverse(sorted)
+			if got := collectKeys(tree.BackwardIterator()); !slices.Equal(got, sorted) {
+				t.Errorf("BackwardIterator = %q, want %q", got, sorted)
+			}
+
+			tree.Clear()
+			if !tree.IsEmpty() || tree.Size() != 0 {
+				t.Error("Clear should empty the tree")
+			}
+			if _, ok := tree.Get(""); ok {
+				t.Error("Empty key should be gone after Clear")
+			}
+		})
+	}
+}
+
+func TestPrefixTreeWalkPrefix(t *testing.T) {
+	for _, impl := range prefixTreeImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			tree := impl.new()
+			for i, key := range []string{"/api/v1/users", "/api/v2/users", "/api/v2/users/me", "/api/v2/orders", "/apix", "/health"} {
+				tree.Insert(key, i)
+			}
+
+			tests := []struct {
+				prefix string
+				want   []string
+			}{
+				{"/api/v2", []string{"/api/v2/orders", "/api/v2/users", "/api/v2/users/me"}},
+				{"/api/v2/users", []string{"/api/v2/users", "/api/v2/users/me"}},
+				{"/api/v2/u", []string{"/api/v2/users", "/api/v2/users/me"}},
+				{"/api", []string{"/api/v1/users", "/api/v2/orders", "/api/v2/users", "/api/v2/users/me", "/apix"}},
+				{"/api/v3", nil},
+				{"/healthz", nil},
+				{"", []string{"/api/v1/users", "/api/v2/orders", "/api/v2/users", "/api/v2/users/me", "/apix", "/health"}},
+			}
+
+			for _, tt := range tests {
+				if got := collectKeys(tree.WalkPrefix(tt.prefix)); !slices.Equal(got, tt.want) {
+					t.Errorf("WalkPrefix(%q) = %q, want %q", tt.prefix, got, tt.want)
+				}
+			}
+
+			// early break stops the walk
+			count := 0
+			for range tree.WalkPrefix("/api") {
+				count++
+				if count == 2 {
+					break
+				}
+			}
+			if count != 2 {
+				t.Errorf("Expected walk to stop after 2 keys, got %d", count)
+			}
+		})
+	}
+}
+
+func TestPrefixTreeLongestPrefixMatch(t *testing.T) {
+	for _, impl := range prefixTreeImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			tree := impl.new()
+			if _, _, ok := tree.LongestPrefixMatch("abc"); ok {
+				t.Error("LongestPrefixMatch on empty tree should return false")
+			}
+
+			tree.Insert("10.0", 1)
+			tree.Insert("10.0.1", 2)
+			tree.Insert("10.0.1.15", 3)
+			tree.Insert("192", 4)
+
+			tests := []struct {
+				key    string
+				want   string
+				value  int
+				wantOk bool
+			}{
+				{"10.0.1.15", "10.0.1.15", 3, true},
+				{"10.0.1.16", "10.0.1", 2, true},
+				{"10.0.2.1", "10.0", 1, true},
+				{"10.0", "10.0", 1, true},
+				{"10", "", 0, false},
+				{"192.168", "192", 4, true},
+				{"8.8.8.8", "", 0, false},
+			}
+
+			for _, tt := range tests {
+				key, value, ok := tree.LongestPrefixMatch(tt.key)
+				if key != tt.want || value != tt.value || ok != tt.wantOk {
+					t.Errorf("LongestPrefixMatch(%q) = %q, %d, %v, want %q, %d, %v", tt.key, key, value, ok, tt.want, tt.value, tt.wantOk)
+				}
+			}
+
+			// empty key matches everything once stored
+			tree.Insert("", 0)
+			if key, value, ok := tree.LongestPrefixMatch("8.8.8.8"); !ok || key != "" || value != 0 {
+				t.Errorf("Expected empty key to match, got %q, %d, %v", key, value, ok)
+			}
+		})
+	}
+}
+
+func TestPrefixTreeUnicode(t *testing.T) {
+	for _, impl := range prefixTreeImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			tree := impl.new()
+			keys := []string{"héllo", "hèllo", "hello", "日本", "日本語", "日曜日", "z"}
+			for i, key := range keys {
+				tree.Insert(key, i)
+			}
+
+			sorted := slices.Clone(keys)
+			sort.Strings(sorted)
+			if got := collectKeys(tree.ForwardIterator()); !slices.Equal(got, sorted) {
+				t.Errorf("ForwardIterator = %q, want %q", got, sorted)
+			}
+			if got := collectKeys(tree.WalkPrefix("日本")); !slices.Equal(got, []string{"日本", "日本語"}) {
+				t.Errorf("WalkPrefix(日本) = %q", got)
+			}
+			if key, _, ok := tree.LongestPrefixMatch("日本語版"); !ok || key != "日本語" {
+				t.Errorf("LongestPrefixMatch(日本語版) = %q, %v", key, ok)
+			}
+
+			for _, key := range keys {
+				if !tree.Delete(key) {
+					t.Errorf("Delete(%q) returned false", key)
+				}
+			}
+			if !tree.IsEmpty() {
+				t.Errorf("Expected empty tree, got size %d", tree.Size())
+			}
+		})
+	}
+}
+
+// Random inserts and deletes over a small alphabet, checked against a builtin map
+func TestPrefixTreeRandomized(t *testing.T) {
+	alphabet := []string{"a", "b", "é", "日"}
+	r := rand.New(rand.NewSource(11))
+	randomKey := func() string {
+		var builder strings.Builder
+		for range r.Intn(6) {
+			builder.WriteString(alphabet[r.Intn(len(alphabet))])
+		}
+		return builder.String()
+	}
+
+	for _, impl := range prefixTreeImplementations {
+		t.Run(impl.name, func(t *testing.T) {
+			tree := impl.new()
+			reference := map[string]int{}
+
+			for i := range 5000 {
+				key := randomKey()
+				if r.Intn(3) == 0 {
+					_, exists := reference[key]
+					if tree.Delete(key) != exists {
+						t.Fatalf("Delete(%q) disagrees with reference", key)
+					}
+					delete(reference, key)
+				} else {
+					tree.Insert(key, i)
+					reference[key] = i
+				}
+			}
+
+			if tree.Size() != len(reference) {
+				t.Fatalf("Size = %d, want %d", tree.Size(), len(reference))
+			}
+
+			var want []string
+			for key := range reference {
+				want = append(want, key)
+			}
+			sort.Strings(want)
+			got := collectKeys(tree.ForwardIterator())
+			if !slices.Equal(got, want) {
+				t.Fatalf("ForwardIterator = %q, want %q", got, want)
+			}
+
+			for range 200 {
+				prefix := randomKey()
+				var wantPrefix []string
+				for _, key := range want {
+					if strings.HasPrefix(key, prefix) {
+						wantPrefix = append(wantPrefix, key)
+					}
+				}
+				if got := collectKeys(tree.WalkPrefix(prefix)); !slices.Equal(got, wantPrefix) {
+					t.Fatalf("WalkPrefix(%q) = %q, want %q", prefix, got, wantPrefix)
+				}
+			}
+		})
+	}
+}
+
+func TestTrieUnits(t *testing.T) {
+	byteTrie, runeTrie := NewTrie[int](), NewRuneTrie[int]()
+	byteTrie.Insert("日本", 1)
+	runeTrie.Insert("日本", 1)
+
+	// one node per byte against one node per character
+	if len(byteTrie.root.children) != 1 || byteTrie.root.children[0].unit != rune("日"[0]) {
+		t.Error("Byte-wise trie should branch on the first byte")
+	}
+	if len(runeTrie.root.children) != 1 || runeTrie.root.children[0].unit != '日' {
+		t.Error("Rune-wise trie should branch on the first rune")
+	}
+
+	// byte-wise tries find keys by a partial rune, rune-wise tries do not
+	if got := collectKeys(byteTrie.WalkPrefix("日本"[:1])); len(got) != 1 {
+		t.Errorf("Byte-wise WalkPrefix by partial rune got %q", got)
+	}
+	if got := collectKeys(runeTrie.WalkPrefix("日本"[:1])); len(got) != 0 {
+		t.Errorf("Rune-wise WalkPrefix by partial rune got %q", got)
+	}
+
+	// deleting a key removes the nodes leading only to it
+	byteTrie.Insert("日", 2)
+	byteTrie.Delete("日本")
+	if node := byteTrie.root.children[0].children[0].children[0]; len(node.children) != 0 {
+		t.Errorf("Expected dead branch to be pruned, got %d children", len(node.children))
+	}
+}